@@ -0,0 +1,161 @@
+// Package catalog is a shared list of common equipment under canonical names,
+// so that "SM58", "sm-58" and "Shure 58" all turn out to be the same mic.
+package catalog
+
+import (
+	_ "embed"
+	"encoding/json"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+type Category string
+
+const (
+	CategoryMic     Category = "mic"
+	CategoryDI      Category = "di"
+	CategoryAmp     Category = "amp"
+	CategoryCab     Category = "cab"
+	CategoryConsole Category = "console"
+)
+
+var Categories = []Category{CategoryMic, CategoryDI, CategoryAmp, CategoryCab, CategoryConsole}
+
+type Item struct {
+	ID       string   `json:"id"`
+	Make     string   `json:"make"`
+	Model    string   `json:"model"`
+	Category Category `json:"category"`
+	// PolarPattern is only set for mics.
+	PolarPattern string `json:"polar_pattern,omitempty"`
+	// Phantom means the item won't work without phantom power.
+	Phantom bool `json:"phantom"`
+	// Aliases are other ways the item is commonly written on riders.
+	Aliases []string `json:"aliases,omitempty"`
+	// Substitutes are the ids of items that are usually accepted in its
+	// place, best first.
+	Substitutes []string `json:"substitutes,omitempty"`
+}
+
+func (item Item) Name() string {
+	return item.Make + " " + item.Model
+}
+
+// names are all the ways of writing the item, normalized.
+func (item Item) names() []string {
+	names := []string{normalize(item.Model), normalize(item.Name())}
+	for _, alias := range item.Aliases {
+		names = append(names, normalize(alias))
+	}
+	return names
+}
+
+//go:embed catalog.json
+var catalogJSON []byte
+
+var (
+	items []Item
+	byID  map[string]Item
+)
+
+func init() {
+	err := json.Unmarshal(catalogJSON, &items)
+	if err != nil {
+		panic("catalog: failed to decode catalog.json: " + err.Error())
+	}
+	byID = make(map[string]Item, len(items))
+	for _, item := range items {
+		if _, ok := byID[item.ID]; ok {
+			panic("catalog: item listed twice: " + item.ID)
+		}
+		byID[item.ID] = item
+	}
+}
+
+func Lookup(id string) (Item, bool) {
+	item, ok := byID[id]
+	return item, ok
+}
+
+// Match finds the catalog item a free text name refers to, if there's exactly
+// one it could be.
+func Match(name string) (Item, bool) {
+	key := normalize(name)
+	if key == "" {
+		return Item{}, false
+	}
+	var found []Item
+	for _, item := range items {
+		if slices.Contains(item.names(), key) {
+			found = append(found, item)
+		}
+	}
+	if len(found) != 1 {
+		return Item{}, false
+	}
+	return found[0], true
+}
+
+// Resolve gives the canonical id for a catalog id or, failing that, a free
+// text name. Names that aren't in the catalog are normalized so that at least
+// spacing and case don't matter when comparing them.
+func Resolve(id, name string) string {
+	if _, ok := byID[id]; ok {
+		return id
+	} else if item, ok := Match(name); ok {
+		return item.ID
+	}
+	return normalize(name)
+}
+
+// Search is for autocomplete. Items whose names start with the query come
+// before those that only contain it, and an empty category matches all.
+func Search(query string, category Category, limit int) []Item {
+	key := normalize(query)
+	type hit struct {
+		item Item
+		rank int
+	}
+	var hits []hit
+	for _, item := range items {
+		if category != "" && item.Category != category {
+			continue
+		}
+		rank := -1
+		for _, name := range item.names() {
+			switch {
+			case key == "" || name == key:
+				rank = 0
+			case strings.HasPrefix(name, key) && (rank == -1 || rank > 1):
+				rank = 1
+			case strings.Contains(name, key) && rank == -1:
+				rank = 2
+			}
+			if rank == 0 {
+				break
+			}
+		}
+		if rank >= 0 {
+			hits = append(hits, hit{item, rank})
+		}
+	}
+	slices.SortStableFunc(hits, func(a, b hit) int {
+		return a.rank - b.rank
+	})
+
+	res := make([]Item, 0, min(len(hits), limit))
+	for _, h := range hits[:min(len(hits), limit)] {
+		res = append(res, h.item)
+	}
+	return res
+}
+
+func normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+}
@@ -0,0 +1,102 @@
+// Package compat checks a rider against a venue's technical spec, so that
+// shortfalls turn up while there's still time to rent or renegotiate rather
+// than at load-in.
+package compat
+
+import (
+	"github.com/jkellogg01/rider/server/rider"
+	"github.com/jkellogg01/rider/server/venue"
+)
+
+type Severity string
+
+const (
+	// SeverityBlocker means the show can't go ahead as the rider describes
+	// it without changing the rider or the room.
+	SeverityBlocker Severity = "blocker"
+	SeverityWarning Severity = "warning"
+	// SeverityRental means the venue is short of something the band can
+	// hire in.
+	SeverityRental Severity = "rental"
+	// SeverityPlanned is a rental the rider already plans on, so it isn't
+	// something the venue is short of.
+	SeverityPlanned Severity = "planned"
+)
+
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	// Item and Quantity say what to rent, for rental and planned findings.
+	Item     string `json:"item,omitempty"`
+	Quantity int    `json:"quantity,omitempty"`
+}
+
+type Report struct {
+	Blockers []Finding `json:"blockers"`
+	Warnings []Finding `json:"warnings"`
+	Rentals  []Finding `json:"rentals"`
+	Planned  []Finding `json:"planned_rentals"`
+}
+
+// OK reports whether nothing stands in the way of the show.
+func (r Report) OK() bool {
+	return len(r.Blockers) == 0
+}
+
+// Rule checks one aspect of a rider against a venue.
+type Rule struct {
+	ID    string
+	Check func(doc rider.Document, spec venue.Spec) []Finding
+}
+
+// Rules are run in order, which is also the order findings are reported in.
+var Rules = []Rule{
+	{"channels", checkChannels},
+	{"monitor_mixes", checkMixes},
+	{"mics", checkMics},
+	{"backline", checkBackline},
+	{"stage", checkStage},
+	{"power", checkPower},
+}
+
+func Check(doc rider.Document, spec venue.Spec) Report {
+	report := Report{
+		Blockers: []Finding{},
+		Warnings: []Finding{},
+		Rentals:  []Finding{},
+		Planned:  []Finding{},
+	}
+	for _, rule := range Rules {
+		for _, finding := range rule.Check(doc, spec) {
+			finding.Rule = rule.ID
+			switch finding.Severity {
+			case SeverityBlocker:
+				report.Blockers = append(report.Blockers, finding)
+			case SeverityRental:
+				report.Rentals = append(report.Rentals, finding)
+			case SeverityPlanned:
+				report.Planned = append(report.Planned, finding)
+			default:
+				report.Warnings = append(report.Warnings, finding)
+			}
+		}
+	}
+	return report
+}
+
+func blocker(message string) Finding {
+	return Finding{Severity: SeverityBlocker, Message: message}
+}
+
+func warning(message string) Finding {
+	return Finding{Severity: SeverityWarning, Message: message}
+}
+
+func rental(item string, quantity int, message string) Finding {
+	return Finding{Severity: SeverityRental, Message: message, Item: item, Quantity: quantity}
+}
+
+func planned(item string, quantity int, message string) Finding {
+	return Finding{Severity: SeverityPlanned, Message: message, Item: item, Quantity: quantity}
+}
@@ -0,0 +1,221 @@
+package compat
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/jkellogg01/rider/server/catalog"
+	"github.com/jkellogg01/rider/server/rider"
+	"github.com/jkellogg01/rider/server/venue"
+)
+
+// continuousLoadLimit is the share of a supply's rating that a load running
+// for the whole show should stay under.
+const continuousLoadLimit = 0.8
+
+func consoleName(spec venue.Spec) string {
+	if spec.Console.Model != "" {
+		return spec.Console.Model
+	}
+	return "venue desk"
+}
+
+func checkChannels(doc rider.Document, spec venue.Spec) []Finding {
+	need := len(doc.InputList)
+	if need == 0 {
+		return nil
+	} else if spec.Console == nil || spec.Console.Channels == 0 {
+		return []Finding{warning("the venue spec doesn't say how many channels the desk has")}
+	}
+
+	have := spec.Console.Channels
+	if need > have {
+		return []Finding{blocker(fmt.Sprintf("the input list needs %d channels but the %s has %d", need, consoleName(spec), have))}
+	}
+	return nil
+}
+
+// checkMixes counts stereo mixes as two sends, since that's what they take up
+// on the desk.
+func checkMixes(doc rider.Document, spec venue.Spec) []Finding {
+	if doc.Monitors == nil || len(doc.Monitors.Mixes) == 0 {
+		return nil
+	}
+	need := 0
+	for _, mix := range doc.Monitors.Mixes {
+		need++
+		if mix.Stereo {
+			need++
+		}
+	}
+	if spec.Console == nil || spec.Console.MonitorMixes == 0 {
+		return []Finding{warning("the venue spec doesn't say how many monitor mixes the desk has")}
+	}
+
+	have := spec.Console.MonitorMixes
+	if need > have {
+		return []Finding{blocker(fmt.Sprintf("the monitor mixes need %d sends but the %s has %d", need, consoleName(spec), have))}
+	}
+	return nil
+}
+
+// checkMics compares the mics on the input list with the venue's locker,
+// leaving out any the band brings from its own inventory.
+func checkMics(doc rider.Document, spec venue.Spec) []Finding {
+	var demands []demand
+	for _, ch := range doc.InputList {
+		if ch.Mic == "" || ch.GearID != 0 {
+			continue
+		}
+		key := catalog.Resolve(ch.CatalogID, ch.Mic)
+		i := slices.IndexFunc(demands, func(d demand) bool { return d.key == key })
+		if i >= 0 {
+			demands[i].quantity++
+			continue
+		}
+		var subs []string
+		if item, ok := catalog.Lookup(key); ok {
+			subs = item.Substitutes
+		}
+		demands = append(demands, demand{key: key, name: ch.Mic, quantity: 1, substitutes: subs})
+	}
+	if len(demands) == 0 {
+		return nil
+	} else if len(spec.Mics) == 0 {
+		return []Finding{warning("the venue spec doesn't list a mic locker, so mics couldn't be checked")}
+	}
+
+	s := stock{}
+	for _, mic := range spec.Mics {
+		s.add(catalog.Resolve(mic.CatalogID, mic.Model), mic.Model, mic.Available())
+	}
+	return s.fill(demands, "the input list uses %d %s but the venue has %d")
+}
+
+// checkBackline looks for what the rider expects the venue to provide in the
+// venue's backline list. Rentals the rider already plans on are reported
+// apart from the venue's shortfalls, noting any the venue has spare.
+func checkBackline(doc rider.Document, spec venue.Spec) []Finding {
+	var demands []demand
+	var rentals []rider.BacklineItem
+	for _, item := range doc.Backline {
+		quantity := max(item.Quantity, 1)
+		switch item.ProvidedBy {
+		case rider.ProvidedByRental:
+			rentals = append(rentals, item)
+		case rider.ProvidedByVenue:
+			var subs []string
+			for _, name := range item.Substitutes {
+				subs = append(subs, catalog.Resolve("", name))
+			}
+			demands = append(demands, demand{
+				key:         catalog.Resolve(item.CatalogID, item.Name),
+				name:        item.Name,
+				quantity:    quantity,
+				substitutes: subs,
+			})
+		}
+	}
+
+	s := stock{}
+	for _, item := range spec.Backline {
+		s.add(catalog.Resolve(item.CatalogID, item.Name), item.Name, item.Available())
+	}
+	findings := s.fill(demands, "the rider expects the venue to provide %d %s but it lists %d")
+
+	// what the venue has left over after the rider's own requests could
+	// stand in for a rental
+	for _, item := range rentals {
+		quantity := max(item.Quantity, 1)
+		spare := 0
+		for spare < quantity {
+			taken, _ := s.take(catalog.Resolve(item.CatalogID, item.Name), quantity-spare)
+			if taken == 0 {
+				break
+			}
+			spare += taken
+		}
+		message := fmt.Sprintf("the rider lists %s as a rental", item.Name)
+		if spare > 0 {
+			message = fmt.Sprintf("the rider lists %d %s as a rental, but the venue has %d spare", quantity, item.Name, spare)
+		}
+		findings = append(findings, planned(item.Name, quantity, message))
+	}
+	return findings
+}
+
+// checkStage compares the space the stage plot's items take up with the
+// venue's stage, so a plot drawn generously still passes if the band fits.
+func checkStage(doc rider.Document, spec venue.Spec) []Finding {
+	if doc.StagePlot == nil {
+		return nil
+	} else if spec.Stage == nil || spec.Stage.Width == 0 || spec.Stage.Depth == 0 {
+		return []Finding{warning("the venue spec doesn't give the stage size")}
+	}
+
+	var width, depth float64
+	for _, item := range doc.StagePlot.Items {
+		width = max(width, item.X+item.Width)
+		depth = max(depth, item.Y+item.Depth)
+	}
+
+	stage := spec.Stage
+	if width > stage.Width || depth > stage.Depth {
+		return []Finding{blocker(fmt.Sprintf(
+			"the stage plot needs %.1fm x %.1fm but the stage is %.1fm x %.1fm", width, depth, stage.Width, stage.Depth,
+		))}
+	} else if doc.StagePlot.Width > stage.Width || doc.StagePlot.Depth > stage.Depth {
+		return []Finding{warning(fmt.Sprintf(
+			"the stage plot is drawn at %.1fm x %.1fm, bigger than the %.1fm x %.1fm stage, though everything on it fits",
+			doc.StagePlot.Width, doc.StagePlot.Depth, stage.Width, stage.Depth,
+		))}
+	}
+	return nil
+}
+
+// checkPower compares what the stage plot draws with the venue's supplies,
+// both in total and at each voltage the band's drops ask for.
+func checkPower(doc rider.Document, spec venue.Spec) []Finding {
+	if doc.StagePlot == nil {
+		return nil
+	}
+	watts := 0
+	for _, item := range doc.StagePlot.Items {
+		if item.Power != nil {
+			watts += item.Power.Watts
+		}
+	}
+	if watts == 0 && len(doc.StagePlot.Drops) == 0 {
+		return nil
+	} else if len(spec.Power) == 0 {
+		return []Finding{warning("the venue spec doesn't list any stage power")}
+	}
+
+	var findings []Finding
+	capacity := 0.0
+	voltages := map[int]bool{}
+	for _, supply := range spec.Power {
+		capacity += float64(supply.Voltage) * supply.Amps * float64(max(supply.Count, 1))
+		voltages[supply.Voltage] = true
+	}
+	if float64(watts) > capacity {
+		findings = append(findings, blocker(fmt.Sprintf("the stage plot draws %dW but the venue's supplies are rated for %.0fW", watts, capacity)))
+	} else if float64(watts) > capacity*continuousLoadLimit {
+		findings = append(findings, warning(fmt.Sprintf(
+			"the stage plot draws %dW, over %.0f%% of the %.0fW the venue's supplies are rated for", watts, continuousLoadLimit*100, capacity,
+		)))
+	}
+
+	for _, drop := range doc.StagePlot.Drops {
+		if drop.Voltage != 0 && !voltages[drop.Voltage] {
+			label := drop.Label
+			if label == "" {
+				label = drop.ID
+			}
+			findings = append(findings, warning(fmt.Sprintf(
+				"drop %s needs %dV but the venue doesn't list a %dV supply, so it needs a transformer", label, drop.Voltage, drop.Voltage,
+			)))
+		}
+	}
+	return findings
+}
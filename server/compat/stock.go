@@ -0,0 +1,90 @@
+package compat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jkellogg01/rider/server/catalog"
+)
+
+// demand is something the rider needs the venue to have. Keys are catalog
+// ids where the catalog knows the item, and normalized names otherwise.
+type demand struct {
+	key         string
+	name        string
+	quantity    int
+	substitutes []string
+}
+
+type stockItem struct {
+	key   string
+	name  string
+	count int
+}
+
+// stock is what the venue has left to hand out as demands are filled.
+type stock []stockItem
+
+func (s *stock) add(key, name string, count int) {
+	if item, ok := catalog.Lookup(key); ok && name == "" {
+		name = item.Name()
+	}
+	*s = append(*s, stockItem{key, name, count})
+}
+
+// take hands out up to n of an item. Items outside the catalog are matched
+// loosely, since spec sheets rarely write out as much of the name as riders.
+func (s stock) take(key string, n int) (int, string) {
+	_, known := catalog.Lookup(key)
+	for i, item := range s {
+		_, itemKnown := catalog.Lookup(item.key)
+		loose := !known && !itemKnown && key != "" && item.key != "" &&
+			(strings.Contains(item.key, key) || strings.Contains(key, item.key))
+		if item.count == 0 || (item.key != key && !loose) {
+			continue
+		}
+		taken := min(n, item.count)
+		s[i].count -= taken
+		return taken, item.name
+	}
+	return 0, ""
+}
+
+// fill hands out stock to each demand, then makes up any shortfall from
+// substitutes. Substitutes are only used once every demand has had its pick
+// of the real thing. Whatever is left short is reported as a rental.
+func (s stock) fill(demands []demand, shortMessage string) []Finding {
+	short := make([]int, len(demands))
+	for i, d := range demands {
+		short[i] = d.quantity
+		for short[i] > 0 {
+			taken, _ := s.take(d.key, short[i])
+			if taken == 0 {
+				break
+			}
+			short[i] -= taken
+		}
+	}
+
+	var findings []Finding
+	for i, d := range demands {
+		if short[i] == 0 {
+			continue
+		}
+		have := d.quantity - short[i]
+		for _, sub := range d.substitutes {
+			for short[i] > 0 {
+				taken, name := s.take(sub, short[i])
+				if taken == 0 {
+					break
+				}
+				short[i] -= taken
+				findings = append(findings, warning(fmt.Sprintf("the venue is short of %s, so %d %s will have to stand in", d.name, taken, name)))
+			}
+		}
+		if short[i] > 0 {
+			findings = append(findings, rental(d.name, short[i], fmt.Sprintf(shortMessage, d.quantity, d.name, have)))
+		}
+	}
+	return findings
+}
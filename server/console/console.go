@@ -0,0 +1,78 @@
+// Package console writes a rider's input list as files that digital mixing
+// desks can load, so nobody has to type channel names in at soundcheck.
+package console
+
+import (
+	"io"
+	"slices"
+)
+
+// Color is a channel strip color. Desks have small, fixed palettes, so riders
+// pick from the colors they all share.
+type Color string
+
+const (
+	ColorNone    Color = ""
+	ColorRed     Color = "red"
+	ColorGreen   Color = "green"
+	ColorYellow  Color = "yellow"
+	ColorBlue    Color = "blue"
+	ColorMagenta Color = "magenta"
+	ColorCyan    Color = "cyan"
+	ColorWhite   Color = "white"
+)
+
+var Colors = []Color{ColorRed, ColorGreen, ColorYellow, ColorBlue, ColorMagenta, ColorCyan, ColorWhite}
+
+func (c Color) Valid() bool {
+	return c == ColorNone || slices.Contains(Colors, c)
+}
+
+type Channel struct {
+	Number  int
+	Name    string
+	Color   Color
+	Phantom bool
+}
+
+type Scene struct {
+	Name     string
+	Channels []Channel
+}
+
+// Exporter writes a scene in one desk's file format. Anything in the scene
+// the desk can't take, like more channels than it has, is skipped and
+// described in the returned warnings rather than failing the export.
+type Exporter interface {
+	// Name is shown to users picking a desk.
+	Name() string
+	Extension() string
+	ContentType() string
+	Export(w io.Writer, scene Scene) ([]string, error)
+}
+
+var exporters = map[string]Exporter{}
+
+// Register makes an exporter available under an id used in URLs. It panics
+// if the id is taken, since that's a programming error.
+func Register(id string, e Exporter) {
+	if _, ok := exporters[id]; ok {
+		panic("console: exporter registered twice: " + id)
+	}
+	exporters[id] = e
+}
+
+func Lookup(id string) (Exporter, bool) {
+	e, ok := exporters[id]
+	return e, ok
+}
+
+// IDs lists the registered exporters in a stable order.
+func IDs() []string {
+	ids := make([]string, 0, len(exporters))
+	for id := range exporters {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
@@ -0,0 +1,84 @@
+package console
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func init() {
+	Register("x32", x32{})
+}
+
+const (
+	x32Channels   = 32
+	x32NameLength = 12
+	// x32DefaultColor is what channels the rider doesn't color get, the
+	// same as a freshly initialized desk.
+	x32DefaultColor = "YE"
+)
+
+var x32Colors = map[Color]string{
+	ColorRed:     "RD",
+	ColorGreen:   "GN",
+	ColorYellow:  "YE",
+	ColorBlue:    "BL",
+	ColorMagenta: "MG",
+	ColorCyan:    "CY",
+	ColorWhite:   "WH",
+}
+
+// x32 writes the text scene files that Behringer X32 and Midas M32 desks
+// load from USB, setting each channel's name, color, input and phantom power.
+// Channels are patched to the local input with the same number.
+type x32 struct{}
+
+func (x32) Name() string        { return "Behringer X32 / Midas M32 scene" }
+func (x32) Extension() string   { return "scn" }
+func (x32) ContentType() string { return "text/plain; charset=us-ascii" }
+
+func (x32) Export(w io.Writer, scene Scene) ([]string, error) {
+	var warnings []string
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "#4.0# \"%s\" \"\" %%000000000 1\n", x32Text(scene.Name, 32))
+
+	for _, ch := range scene.Channels {
+		if ch.Number < 1 || ch.Number > x32Channels {
+			warnings = append(warnings, fmt.Sprintf("channel %d (%s) is past the desk's %d channels and was left out", ch.Number, ch.Name, x32Channels))
+			continue
+		}
+		name := x32Text(ch.Name, x32NameLength)
+		if name != ch.Name {
+			warnings = append(warnings, fmt.Sprintf("channel %d was renamed %q to fit the desk", ch.Number, name))
+		}
+		color, ok := x32Colors[ch.Color]
+		if !ok {
+			color = x32DefaultColor
+		}
+		phantom := "OFF"
+		if ch.Phantom {
+			phantom = "ON"
+		}
+
+		fmt.Fprintf(out, "/ch/%02d/config \"%s\" 1 %s %d\n", ch.Number, name, color, ch.Number)
+		fmt.Fprintf(out, "/headamp/%03d +0.0 %s\n", ch.Number-1, phantom)
+	}
+	return warnings, out.Flush()
+}
+
+// x32Text limits s to the printable ASCII the desk can show, without the
+// double quotes that delimit it, and to its field length. The result goes
+// between quotes as it is, since the desk doesn't read escapes.
+func x32Text(s string, length int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e || r == '"' {
+			return -1
+		}
+		return r
+	}, s)
+	if len(s) > length {
+		s = strings.TrimSpace(s[:length])
+	}
+	return s
+}
@@ -14,7 +14,7 @@ insert into account (
   email, password, given_name, family_name
 ) values (
   $1, $2, $3, $4
-) returning id, created_at, updated_at, given_name, family_name, email, password
+) returning id, created_at, updated_at, given_name, family_name, email, password, dietary_restrictions
 `
 
 type CreateAccountParams struct {
@@ -40,6 +40,7 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.FamilyName,
 		&i.Email,
 		&i.Password,
+		&i.DietaryRestrictions,
 	)
 	return i, err
 }
@@ -55,7 +56,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int32) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-select id, created_at, updated_at, given_name, family_name, email, password from account
+select id, created_at, updated_at, given_name, family_name, email, password, dietary_restrictions from account
 where id = $1 limit 1
 `
 
@@ -70,12 +71,13 @@ func (q *Queries) GetAccount(ctx context.Context, id int32) (Account, error) {
 		&i.FamilyName,
 		&i.Email,
 		&i.Password,
+		&i.DietaryRestrictions,
 	)
 	return i, err
 }
 
 const getAccountByEmail = `-- name: GetAccountByEmail :one
-select id, created_at, updated_at, given_name, family_name, email, password from account
+select id, created_at, updated_at, given_name, family_name, email, password, dietary_restrictions from account
 where email = $1 limit 1
 `
 
@@ -90,12 +92,13 @@ func (q *Queries) GetAccountByEmail(ctx context.Context, email string) (Account,
 		&i.FamilyName,
 		&i.Email,
 		&i.Password,
+		&i.DietaryRestrictions,
 	)
 	return i, err
 }
 
 const getAllAccounts = `-- name: GetAllAccounts :many
-select id, created_at, updated_at, given_name, family_name, email, password from account
+select id, created_at, updated_at, given_name, family_name, email, password, dietary_restrictions from account
 order by id
 `
 
@@ -116,6 +119,7 @@ func (q *Queries) GetAllAccounts(ctx context.Context) ([]Account, error) {
 			&i.FamilyName,
 			&i.Email,
 			&i.Password,
+			&i.DietaryRestrictions,
 		); err != nil {
 			return nil, err
 		}
@@ -134,7 +138,7 @@ const updateAccount = `-- name: UpdateAccount :one
 update account
   set email = $2, password = $3, given_name = $4, family_name = $5, updated_at = NOW()
 where id = $1
-returning id, created_at, updated_at, given_name, family_name, email, password
+returning id, created_at, updated_at, given_name, family_name, email, password, dietary_restrictions
 `
 
 type UpdateAccountParams struct {
@@ -162,6 +166,35 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.FamilyName,
 		&i.Email,
 		&i.Password,
+		&i.DietaryRestrictions,
+	)
+	return i, err
+}
+
+const updateAccountDietaryRestrictions = `-- name: UpdateAccountDietaryRestrictions :one
+update account
+  set dietary_restrictions = $2, updated_at = NOW()
+where id = $1
+returning id, created_at, updated_at, given_name, family_name, email, password, dietary_restrictions
+`
+
+type UpdateAccountDietaryRestrictionsParams struct {
+	ID                  int32  `json:"id"`
+	DietaryRestrictions string `json:"dietary_restrictions"`
+}
+
+func (q *Queries) UpdateAccountDietaryRestrictions(ctx context.Context, arg UpdateAccountDietaryRestrictionsParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountDietaryRestrictions, arg.ID, arg.DietaryRestrictions)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.GivenName,
+		&i.FamilyName,
+		&i.Email,
+		&i.Password,
+		&i.DietaryRestrictions,
 	)
 	return i, err
 }
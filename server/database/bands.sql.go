@@ -54,6 +54,31 @@ func (q *Queries) CreateBand(ctx context.Context, name string) (Band, error) {
 	return i, err
 }
 
+const getAccountBand = `-- name: GetAccountBand :one
+select id, account_id, band_id, created_at, updated_at, account_is_admin from account_band
+where account_id = $1 and band_id = $2
+limit 1
+`
+
+type GetAccountBandParams struct {
+	AccountID int32 `json:"account_id"`
+	BandID    int32 `json:"band_id"`
+}
+
+func (q *Queries) GetAccountBand(ctx context.Context, arg GetAccountBandParams) (AccountBand, error) {
+	row := q.db.QueryRowContext(ctx, getAccountBand, arg.AccountID, arg.BandID)
+	var i AccountBand
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.BandID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AccountIsAdmin,
+	)
+	return i, err
+}
+
 const getAccountBands = `-- name: GetAccountBands :many
 select 
   ab.account_id, 
@@ -135,3 +160,61 @@ func (q *Queries) GetBand(ctx context.Context, arg GetBandParams) (Band, error)
 	)
 	return i, err
 }
+
+const getBandByID = `-- name: GetBandByID :one
+select id, created_at, updated_at, name from band where id = $1 limit 1
+`
+
+func (q *Queries) GetBandByID(ctx context.Context, id int32) (Band, error) {
+	row := q.db.QueryRowContext(ctx, getBandByID, id)
+	var i Band
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+	)
+	return i, err
+}
+
+const getBandMemberDietaryRestrictions = `-- name: GetBandMemberDietaryRestrictions :many
+select a.given_name, a.family_name, a.dietary_restrictions
+from account a
+join account_band ab
+on ab.account_id = a.id and ab.band_id = $1
+where a.dietary_restrictions <> ''
+order by a.given_name, a.family_name
+`
+
+type GetBandMemberDietaryRestrictionsRow struct {
+	GivenName           string `json:"given_name"`
+	FamilyName          string `json:"family_name"`
+	DietaryRestrictions string `json:"dietary_restrictions"`
+}
+
+func (q *Queries) GetBandMemberDietaryRestrictions(ctx context.Context, bandID int32) ([]GetBandMemberDietaryRestrictionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getBandMemberDietaryRestrictions, bandID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetBandMemberDietaryRestrictionsRow
+	for rows.Next() {
+		var i GetBandMemberDietaryRestrictionsRow
+		if err := rows.Scan(
+			&i.GivenName,
+			&i.FamilyName,
+			&i.DietaryRestrictions,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -5,17 +5,242 @@
 package database
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
+type PackingStage string
+
+const (
+	PackingStageLoadIn  PackingStage = "load_in"
+	PackingStageLoadOut PackingStage = "load_out"
+)
+
+func (e *PackingStage) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PackingStage(s)
+	case string:
+		*e = PackingStage(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PackingStage: %T", src)
+	}
+	return nil
+}
+
+type NullPackingStage struct {
+	PackingStage PackingStage `json:"packing_stage"`
+	Valid        bool         `json:"valid"` // Valid is true if PackingStage is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPackingStage) Scan(value interface{}) error {
+	if value == nil {
+		ns.PackingStage, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PackingStage.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPackingStage) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PackingStage), nil
+}
+
+type RiderStatus string
+
+const (
+	RiderStatusDraft      RiderStatus = "draft"
+	RiderStatusInReview   RiderStatus = "in_review"
+	RiderStatusPublished  RiderStatus = "published"
+	RiderStatusSuperseded RiderStatus = "superseded"
+)
+
+func (e *RiderStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RiderStatus(s)
+	case string:
+		*e = RiderStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RiderStatus: %T", src)
+	}
+	return nil
+}
+
+type NullRiderStatus struct {
+	RiderStatus RiderStatus `json:"rider_status"`
+	Valid       bool        `json:"valid"` // Valid is true if RiderStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRiderStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.RiderStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RiderStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRiderStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RiderStatus), nil
+}
+
+type ScheduleKind string
+
+const (
+	ScheduleKindTravel     ScheduleKind = "travel"
+	ScheduleKindLoadIn     ScheduleKind = "load_in"
+	ScheduleKindSoundcheck ScheduleKind = "soundcheck"
+	ScheduleKindDoors      ScheduleKind = "doors"
+	ScheduleKindSupport    ScheduleKind = "support"
+	ScheduleKindSet        ScheduleKind = "set"
+	ScheduleKindCurfew     ScheduleKind = "curfew"
+	ScheduleKindLoadOut    ScheduleKind = "load_out"
+	ScheduleKindOther      ScheduleKind = "other"
+)
+
+func (e *ScheduleKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ScheduleKind(s)
+	case string:
+		*e = ScheduleKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ScheduleKind: %T", src)
+	}
+	return nil
+}
+
+type NullScheduleKind struct {
+	ScheduleKind ScheduleKind `json:"schedule_kind"`
+	Valid        bool         `json:"valid"` // Valid is true if ScheduleKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullScheduleKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.ScheduleKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ScheduleKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullScheduleKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ScheduleKind), nil
+}
+
+type ShareResponseKind string
+
+const (
+	ShareResponseKindAcknowledged ShareResponseKind = "acknowledged"
+	ShareResponseKindFlagged      ShareResponseKind = "flagged"
+)
+
+func (e *ShareResponseKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ShareResponseKind(s)
+	case string:
+		*e = ShareResponseKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ShareResponseKind: %T", src)
+	}
+	return nil
+}
+
+type NullShareResponseKind struct {
+	ShareResponseKind ShareResponseKind `json:"share_response_kind"`
+	Valid             bool              `json:"valid"` // Valid is true if ShareResponseKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullShareResponseKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.ShareResponseKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ShareResponseKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullShareResponseKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ShareResponseKind), nil
+}
+
+type ShowStatus string
+
+const (
+	ShowStatusHold      ShowStatus = "hold"
+	ShowStatusConfirmed ShowStatus = "confirmed"
+	ShowStatusCancelled ShowStatus = "cancelled"
+)
+
+func (e *ShowStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ShowStatus(s)
+	case string:
+		*e = ShowStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ShowStatus: %T", src)
+	}
+	return nil
+}
+
+type NullShowStatus struct {
+	ShowStatus ShowStatus `json:"show_status"`
+	Valid      bool       `json:"valid"` // Valid is true if ShowStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullShowStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ShowStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ShowStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullShowStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ShowStatus), nil
+}
+
 type Account struct {
-	ID         int32     `json:"id"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
-	GivenName  string    `json:"given_name"`
-	FamilyName string    `json:"family_name"`
-	Email      string    `json:"email"`
-	Password   string    `json:"password"`
+	ID                  int32     `json:"id"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+	GivenName           string    `json:"given_name"`
+	FamilyName          string    `json:"family_name"`
+	Email               string    `json:"email"`
+	Password            string    `json:"password"`
+	DietaryRestrictions string    `json:"dietary_restrictions"`
 }
 
 type AccountBand struct {
@@ -34,6 +259,44 @@ type Band struct {
 	Name      string    `json:"name"`
 }
 
+type CalendarToken struct {
+	ID        int32     `json:"id"`
+	AccountID int32     `json:"account_id"`
+	Token     string    `json:"token"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Gear struct {
+	ID              int32         `json:"id"`
+	BandID          int32         `json:"band_id"`
+	OwnerID         sql.NullInt32 `json:"owner_id"`
+	Name            string        `json:"name"`
+	Category        string        `json:"category"`
+	Make            string        `json:"make"`
+	Model           string        `json:"model"`
+	SerialNumber    string        `json:"serial_number"`
+	WeightKg        float64       `json:"weight_kg"`
+	WidthCm         float64       `json:"width_cm"`
+	DepthCm         float64       `json:"depth_cm"`
+	HeightCm        float64       `json:"height_cm"`
+	PowerWatts      int32         `json:"power_watts"`
+	ValueCents      int32         `json:"value_cents"`
+	Currency        string        `json:"currency"`
+	Notes           string        `json:"notes"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+	CaseName        string        `json:"case_name"`
+	CountryOfOrigin string        `json:"country_of_origin"`
+}
+
+type GearPhoto struct {
+	ID          int32     `json:"id"`
+	GearID      int32     `json:"gear_id"`
+	ContentType string    `json:"content_type"`
+	Data        []byte    `json:"data"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
 type Invitation struct {
 	ID        int32     `json:"id"`
 	Body      string    `json:"body"`
@@ -42,3 +305,140 @@ type Invitation struct {
 	CreatedAt time.Time `json:"created_at"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
+
+type PackingCheck struct {
+	ID        int32        `json:"id"`
+	ShowID    int32        `json:"show_id"`
+	ItemKey   string       `json:"item_key"`
+	Stage     PackingStage `json:"stage"`
+	CheckedBy int32        `json:"checked_by"`
+	CheckedAt time.Time    `json:"checked_at"`
+}
+
+type Rider struct {
+	ID        int32     `json:"id"`
+	BandID    int32     `json:"band_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type RiderRevision struct {
+	ID          int32           `json:"id"`
+	RiderID     int32           `json:"rider_id"`
+	Revision    int32           `json:"revision"`
+	Status      RiderStatus     `json:"status"`
+	Document    json.RawMessage `json:"document"`
+	AuthorID    int32           `json:"author_id"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	PublishedAt sql.NullTime    `json:"published_at"`
+	PublishedBy sql.NullInt32   `json:"published_by"`
+}
+
+type ScheduleItem struct {
+	ID        int32        `json:"id"`
+	ShowID    int32        `json:"show_id"`
+	Kind      ScheduleKind `json:"kind"`
+	Label     string       `json:"label"`
+	StartsAt  time.Time    `json:"starts_at"`
+	EndsAt    sql.NullTime `json:"ends_at"`
+	Location  string       `json:"location"`
+	Notes     string       `json:"notes"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+type ShareLink struct {
+	ID             int32         `json:"id"`
+	RiderID        int32         `json:"rider_id"`
+	Token          string        `json:"token"`
+	CreatorID      int32         `json:"creator_id"`
+	CreatedAt      time.Time     `json:"created_at"`
+	ExpiresAt      sql.NullTime  `json:"expires_at"`
+	RevokedAt      sql.NullTime  `json:"revoked_at"`
+	ViewCount      int32         `json:"view_count"`
+	LastAccessedAt sql.NullTime  `json:"last_accessed_at"`
+	ShowID         sql.NullInt32 `json:"show_id"`
+}
+
+type ShareResponse struct {
+	ID          int32             `json:"id"`
+	ShareLinkID int32             `json:"share_link_id"`
+	RevisionID  int32             `json:"revision_id"`
+	Kind        ShareResponseKind `json:"kind"`
+	ContactName string            `json:"contact_name"`
+	ContactRole string            `json:"contact_role"`
+	LineItem    string            `json:"line_item"`
+	Comment     string            `json:"comment"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+type Show struct {
+	ID              int32           `json:"id"`
+	BandID          int32           `json:"band_id"`
+	Date            time.Time       `json:"date"`
+	VenueName       string          `json:"venue_name"`
+	City            string          `json:"city"`
+	Timezone        string          `json:"timezone"`
+	LoadInAt        sql.NullTime    `json:"load_in_at"`
+	SoundcheckAt    sql.NullTime    `json:"soundcheck_at"`
+	DoorsAt         sql.NullTime    `json:"doors_at"`
+	SetAt           sql.NullTime    `json:"set_at"`
+	Status          ShowStatus      `json:"status"`
+	RiderRevisionID sql.NullInt32   `json:"rider_revision_id"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	Sequence        int32           `json:"sequence"`
+	RiderOverrides  json.RawMessage `json:"rider_overrides"`
+	VenueID         sql.NullInt32   `json:"venue_id"`
+	ParkingNotes    string          `json:"parking_notes"`
+	WifiNotes       string          `json:"wifi_notes"`
+	DayNotes        string          `json:"day_notes"`
+	TourID          sql.NullInt32   `json:"tour_id"`
+	Latitude        sql.NullFloat64 `json:"latitude"`
+	Longitude       sql.NullFloat64 `json:"longitude"`
+}
+
+type StageSymbol struct {
+	ID        int32     `json:"id"`
+	BandID    int32     `json:"band_id"`
+	ItemType  string    `json:"item_type"`
+	Svg       string    `json:"svg"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Tour struct {
+	ID        int32     `json:"id"`
+	BandID    int32     `json:"band_id"`
+	Name      string    `json:"name"`
+	StartsOn  time.Time `json:"starts_on"`
+	EndsOn    time.Time `json:"ends_on"`
+	Notes     string    `json:"notes"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TourPerson struct {
+	ID        int32         `json:"id"`
+	TourID    int32         `json:"tour_id"`
+	AccountID sql.NullInt32 `json:"account_id"`
+	Name      string        `json:"name"`
+	Role      string        `json:"role"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type Venue struct {
+	ID        int32           `json:"id"`
+	BandID    int32           `json:"band_id"`
+	Name      string          `json:"name"`
+	City      string          `json:"city"`
+	Address   string          `json:"address"`
+	Timezone  string          `json:"timezone"`
+	Spec      json.RawMessage `json:"spec"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Latitude  sql.NullFloat64 `json:"latitude"`
+	Longitude sql.NullFloat64 `json:"longitude"`
+}
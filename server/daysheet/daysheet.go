@@ -0,0 +1,187 @@
+// Package daysheet lays out everything the touring party needs on a show day,
+// as a PDF to print and as plain text short enough to send as a message.
+package daysheet
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jkellogg01/rider/server/pdf"
+)
+
+type Sheet struct {
+	Title string
+	// Date is the show's date. Schedule items on other days, like a load out
+	// after midnight, are printed with their weekday.
+	Date     time.Time
+	Location *time.Location
+	Venue    string
+	Address  string
+	Items    []Item
+	Contacts []Contact
+	Parking  string
+	WiFi     string
+	Notes    string
+}
+
+// Item is one entry in the day's schedule. Times are in the sheet's
+// Location.
+type Item struct {
+	Label    string     `json:"label"`
+	Start    time.Time  `json:"start"`
+	End      *time.Time `json:"end,omitempty"`
+	Location string     `json:"location,omitempty"`
+	Notes    string     `json:"notes,omitempty"`
+}
+
+type Contact struct {
+	Name  string `json:"name"`
+	Role  string `json:"role,omitempty"`
+	Phone string `json:"phone,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+func (c Contact) String() string {
+	s := c.Name
+	if c.Role != "" {
+		s = fmt.Sprintf("%s (%s)", s, c.Role)
+	}
+	for _, detail := range []string{c.Phone, c.Email} {
+		if detail != "" {
+			s += " " + detail
+		}
+	}
+	return s
+}
+
+// Schedule is the sheet's items in the order they happen.
+func (s Sheet) Schedule() []Item {
+	items := slices.Clone(s.Items)
+	slices.SortStableFunc(items, func(a, b Item) int {
+		return a.Start.Compare(b.Start)
+	})
+	return items
+}
+
+// Timezone describes the timezone every time on the sheet is in.
+func (s Sheet) Timezone() string {
+	loc := s.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	abbr := time.Date(s.Date.Year(), s.Date.Month(), s.Date.Day(), 12, 0, 0, 0, loc).Format("MST")
+	if abbr == loc.String() {
+		return abbr
+	}
+	return fmt.Sprintf("%s (%s)", abbr, loc)
+}
+
+func (s Sheet) clock(t time.Time) string {
+	if t.Year() == s.Date.Year() && t.YearDay() == s.Date.YearDay() {
+		return t.Format("15:04")
+	}
+	return t.Format("Mon 15:04")
+}
+
+func (s Sheet) when(item Item) string {
+	if item.End == nil {
+		return s.clock(item.Start)
+	}
+	return fmt.Sprintf("%s-%s", s.clock(item.Start), s.clock(*item.End))
+}
+
+// WriteText writes the sheet as plain text, laid out to read well on a
+// phone.
+func (s Sheet) WriteText(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n%s\n", s.Title, s.Date.Format("Mon 2 Jan 2006"))
+	fmt.Fprintf(&b, "All times %s\n", s.Timezone())
+
+	if items := s.Schedule(); len(items) > 0 {
+		b.WriteString("\n")
+		for _, item := range items {
+			fmt.Fprintf(&b, "%s %s", s.when(item), item.Label)
+			if item.Location != "" {
+				fmt.Fprintf(&b, " @ %s", item.Location)
+			}
+			b.WriteString("\n")
+			if item.Notes != "" {
+				fmt.Fprintf(&b, "  %s\n", item.Notes)
+			}
+		}
+	}
+
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "Venue: %s\n", s.Venue)
+	if s.Address != "" {
+		fmt.Fprintf(&b, "Address: %s\n", s.Address)
+	}
+	if s.Parking != "" {
+		fmt.Fprintf(&b, "Parking: %s\n", s.Parking)
+	}
+	if s.WiFi != "" {
+		fmt.Fprintf(&b, "Wi-Fi: %s\n", s.WiFi)
+	}
+
+	if len(s.Contacts) > 0 {
+		b.WriteString("\nContacts\n")
+		for _, contact := range s.Contacts {
+			fmt.Fprintf(&b, "%s\n", contact)
+		}
+	}
+	if s.Notes != "" {
+		fmt.Fprintf(&b, "\n%s\n", s.Notes)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (s Sheet) WritePDF(w io.Writer) error {
+	doc := pdf.New()
+	doc.Title(s.Title)
+	doc.Paragraph(fmt.Sprintf("%s, all times %s", s.Date.Format("Monday 2 January 2006"), s.Timezone()))
+
+	doc.Heading("Schedule")
+	if items := s.Schedule(); len(items) > 0 {
+		rows := make([][]string, len(items))
+		for i, item := range items {
+			rows[i] = []string{s.when(item), item.Label, item.Location, item.Notes}
+		}
+		doc.Table([]string{"Time", "What", "Where", "Notes"}, rows)
+	} else {
+		doc.Paragraph("Nothing has been scheduled yet.")
+	}
+
+	doc.Heading("Venue")
+	lines := []string{s.Venue}
+	if s.Address != "" {
+		lines = append(lines, s.Address)
+	}
+	doc.Paragraph(strings.Join(lines, "\n"))
+	if s.Parking != "" {
+		doc.Heading("Parking")
+		doc.Paragraph(s.Parking)
+	}
+	if s.WiFi != "" {
+		doc.Heading("Wi-Fi")
+		doc.Paragraph(s.WiFi)
+	}
+
+	if len(s.Contacts) > 0 {
+		doc.Heading("Contacts")
+		rows := make([][]string, len(s.Contacts))
+		for i, c := range s.Contacts {
+			rows[i] = []string{c.Name, c.Role, c.Phone, c.Email}
+		}
+		doc.Table([]string{"Name", "Role", "Phone", "Email"}, rows)
+	}
+	if s.Notes != "" {
+		doc.Heading("Notes")
+		doc.Paragraph(s.Notes)
+	}
+	return doc.Write(w)
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/jkellogg01/rider/server/database"
+)
+
+// bandAccess resolves the band named by the band_id query parameter and the
+// current user's membership in it.
+//
+// Like the other helpers in this package that take the ResponseWriter and
+// return a bool, it has already written an error response when it returns
+// false, so the handler only needs to return.
+func (cfg *config) bandAccess(w http.ResponseWriter, r *http.Request) (database.AccountBand, bool) {
+	bandID, err := strconv.Atoi(r.URL.Query().Get("band_id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "invalid band id")
+		return database.AccountBand{}, false
+	}
+	return cfg.membership(w, r, int32(bandID))
+}
+
+// riderAccess resolves the rider named by the rider_id path value and the
+// current user's membership in the band that owns it.
+func (cfg *config) riderAccess(w http.ResponseWriter, r *http.Request) (database.Rider, database.AccountBand, bool) {
+	riderID, err := strconv.Atoi(r.PathValue("rider_id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "invalid rider id")
+		return database.Rider{}, database.AccountBand{}, false
+	}
+
+	rd, err := cfg.db.GetRider(r.Context(), int32(riderID))
+	if errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusNotFound, "no matching rider")
+		return database.Rider{}, database.AccountBand{}, false
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return database.Rider{}, database.AccountBand{}, false
+	}
+
+	membership, ok := cfg.membership(w, r, rd.BandID)
+	return rd, membership, ok
+}
+
+// showAccess resolves the show named by the show_id path value and the
+// current user's membership in the band playing it.
+func (cfg *config) showAccess(w http.ResponseWriter, r *http.Request) (database.Show, database.AccountBand, bool) {
+	showID, err := strconv.Atoi(r.PathValue("show_id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "invalid show id")
+		return database.Show{}, database.AccountBand{}, false
+	}
+
+	show, err := cfg.db.GetShow(r.Context(), int32(showID))
+	if errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusNotFound, "no matching show")
+		return database.Show{}, database.AccountBand{}, false
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return database.Show{}, database.AccountBand{}, false
+	}
+
+	membership, ok := cfg.membership(w, r, show.BandID)
+	return show, membership, ok
+}
+
+func (cfg *config) membership(w http.ResponseWriter, r *http.Request, bandID int32) (database.AccountBand, bool) {
+	id, ok := r.Context().Value("current-user").(int)
+	if !ok {
+		RespondWithError(w, http.StatusBadRequest, "invalid or missing user id")
+		return database.AccountBand{}, false
+	}
+
+	membership, err := cfg.db.GetAccountBand(r.Context(), database.GetAccountBandParams{
+		AccountID: int32(id),
+		BandID:    bandID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// NOTE: not a 403 so that we don't confirm the band exists to outsiders
+		RespondWithError(w, http.StatusNotFound, "no matching band")
+		return database.AccountBand{}, false
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return database.AccountBand{}, false
+	}
+	return membership, true
+}
+
+// gearAccess resolves the inventory item named by the gear_id path value and
+// the current user's membership in the band that keeps it.
+func (cfg *config) gearAccess(w http.ResponseWriter, r *http.Request) (database.Gear, database.AccountBand, bool) {
+	gearID, err := strconv.Atoi(r.PathValue("gear_id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "invalid gear id")
+		return database.Gear{}, database.AccountBand{}, false
+	}
+
+	gear, err := cfg.db.GetGear(r.Context(), int32(gearID))
+	if errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusNotFound, "no matching gear")
+		return database.Gear{}, database.AccountBand{}, false
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return database.Gear{}, database.AccountBand{}, false
+	}
+
+	membership, ok := cfg.membership(w, r, gear.BandID)
+	return gear, membership, ok
+}
+
+// venueAccess resolves the venue named by the venue_id path value and the
+// current user's membership in the band that keeps it.
+func (cfg *config) venueAccess(w http.ResponseWriter, r *http.Request) (database.Venue, database.AccountBand, bool) {
+	venueID, err := strconv.Atoi(r.PathValue("venue_id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "invalid venue id")
+		return database.Venue{}, database.AccountBand{}, false
+	}
+
+	venue, err := cfg.db.GetVenue(r.Context(), int32(venueID))
+	if errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusNotFound, "no matching venue")
+		return database.Venue{}, database.AccountBand{}, false
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return database.Venue{}, database.AccountBand{}, false
+	}
+
+	membership, ok := cfg.membership(w, r, venue.BandID)
+	return venue, membership, ok
+}
+
+// tourAccess resolves the tour named by the tour_id path value and the
+// current user's membership in the band going on it.
+func (cfg *config) tourAccess(w http.ResponseWriter, r *http.Request) (database.Tour, database.AccountBand, bool) {
+	tourID, err := strconv.Atoi(r.PathValue("tour_id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "invalid tour id")
+		return database.Tour{}, database.AccountBand{}, false
+	}
+
+	tour, err := cfg.db.GetTour(r.Context(), int32(tourID))
+	if errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusNotFound, "no matching tour")
+		return database.Tour{}, database.AccountBand{}, false
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return database.Tour{}, database.AccountBand{}, false
+	}
+
+	membership, ok := cfg.membership(w, r, tour.BandID)
+	return tour, membership, ok
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/rider"
+)
+
+const maxResponseFormBytes = 64 << 10
+
+func (cfg *config) AcknowledgeSharedRider(w http.ResponseWriter, r *http.Request) {
+	cfg.respondToSharedRider(w, r, database.ShareResponseKindAcknowledged)
+}
+
+func (cfg *config) FlagSharedRider(w http.ResponseWriter, r *http.Request) {
+	cfg.respondToSharedRider(w, r, database.ShareResponseKindFlagged)
+}
+
+// respondToSharedRider records a venue contact's response to the revision
+// currently behind a share link. It handles plain form posts from the share
+// page, so it redirects back to that page rather than responding with JSON.
+func (cfg *config) respondToSharedRider(w http.ResponseWriter, r *http.Request, kind database.ShareResponseKind) {
+	shared, ok := cfg.resolveShareLink(w, r)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxResponseFormBytes)
+	err := r.ParseForm()
+	if err != nil {
+		http.Error(w, "failed to read form", http.StatusBadRequest)
+		return
+	}
+
+	name := strings.TrimSpace(r.PostForm.Get("name"))
+	role := strings.TrimSpace(r.PostForm.Get("role"))
+	lineItem := r.PostForm.Get("line_item")
+	comment := strings.TrimSpace(r.PostForm.Get("comment"))
+	if name == "" {
+		http.Error(w, "please tell the band who you are", http.StatusBadRequest)
+		return
+	}
+
+	if kind == database.ShareResponseKindFlagged {
+		known := slices.ContainsFunc(shared.document.LineItems(), func(item rider.LineItem) bool {
+			return item.Key == lineItem
+		})
+		if !known {
+			http.Error(w, "that line item is not part of this rider", http.StatusBadRequest)
+			return
+		} else if comment == "" {
+			http.Error(w, "please tell the band what the problem is", http.StatusBadRequest)
+			return
+		}
+	} else {
+		lineItem = ""
+	}
+
+	_, err = cfg.db.CreateShareResponse(r.Context(), database.CreateShareResponseParams{
+		ShareLinkID: shared.link.ID,
+		RevisionID:  shared.revision.ID,
+		Kind:        kind,
+		ContactName: name,
+		ContactRole: role,
+		LineItem:    lineItem,
+		Comment:     comment,
+	})
+	if err != nil {
+		log.Printf("failed to record share response: %v", err)
+		http.Error(w, "failed to record your response, please try again", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/share/%s?thanks", shared.link.Token), http.StatusSeeOther)
+}
+
+// GetRiderAdvance reports, for each share link on a rider, how far the venue
+// has gotten with the revision the link serves: the show's revision for a link
+// made from a show, and the currently published revision otherwise.
+func (cfg *config) GetRiderAdvance(w http.ResponseWriter, r *http.Request) {
+	rd, _, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	}
+
+	var current, currentID int32
+	published, err := cfg.db.GetPublishedRiderRevision(r.Context(), rd.ID)
+	if err == nil {
+		current, currentID = published.Revision, published.ID
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	links, err := cfg.db.GetRiderShareLinks(r.Context(), rd.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	responses, err := cfg.db.GetRiderShareResponses(r.Context(), rd.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	res := make([]map[string]any, 0, len(links))
+	for _, link := range links {
+		served := currentID
+		if link.ShowID.Valid {
+			show, err := cfg.db.GetShow(r.Context(), link.ShowID.Int32)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+				return
+			}
+			// a show without a rider serves nothing, so nothing is current
+			served = show.RiderRevisionID.Int32
+		}
+
+		acknowledgements := []database.GetRiderShareResponsesRow{}
+		flags := []database.GetRiderShareResponsesRow{}
+		acknowledged, flagged := false, false
+		for _, response := range responses {
+			if response.ShareLinkID != link.ID {
+				continue
+			}
+			isCurrent := served != 0 && response.RevisionID == served
+			switch response.Kind {
+			case database.ShareResponseKindAcknowledged:
+				acknowledgements = append(acknowledgements, response)
+				acknowledged = acknowledged || isCurrent
+			case database.ShareResponseKindFlagged:
+				flags = append(flags, response)
+				flagged = flagged || isCurrent
+			}
+		}
+
+		status := "sent"
+		switch {
+		case flagged:
+			status = "flagged"
+		case acknowledged:
+			status = "acknowledged"
+		case link.ViewCount > 0:
+			status = "viewed"
+		}
+
+		res = append(res, map[string]any{
+			"link":             shareLinkResponse(link),
+			"status":           status,
+			"acknowledgements": acknowledgements,
+			"flags":            flags,
+		})
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]any{
+		"rider_id":           rd.ID,
+		"published_revision": current,
+		"links":              res,
+	})
+}
@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/ical"
+)
+
+// RotateCalendarFeed issues the current user a new secret calendar token,
+// which also invalidates any feed URL they handed out before.
+func (cfg *config) RotateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	id, ok := r.Context().Value("current-user").(int)
+	if !ok {
+		RespondWithError(w, http.StatusBadRequest, "invalid or missing user id")
+		return
+	}
+
+	token, err := generateSecretToken()
+	if err != nil {
+		log.Printf("failed to generate calendar token: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to generate a calendar feed")
+		return
+	}
+
+	calendarToken, err := cfg.db.UpsertCalendarToken(r.Context(), database.UpsertCalendarTokenParams{
+		AccountID: int32(id),
+		Token:     token,
+	})
+	if err != nil {
+		log.Printf("failed to store calendar token: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	cfg.respondWithCalendarFeeds(w, r, calendarToken, http.StatusCreated)
+}
+
+func (cfg *config) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	id, ok := r.Context().Value("current-user").(int)
+	if !ok {
+		RespondWithError(w, http.StatusBadRequest, "invalid or missing user id")
+		return
+	}
+
+	calendarToken, err := cfg.db.GetCalendarToken(r.Context(), int32(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusNotFound, "no calendar feed has been created yet")
+		return
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	cfg.respondWithCalendarFeeds(w, r, calendarToken, http.StatusOK)
+}
+
+func (cfg *config) respondWithCalendarFeeds(w http.ResponseWriter, r *http.Request, calendarToken database.CalendarToken, status int) {
+	bands, err := cfg.db.GetAccountBands(r.Context(), calendarToken.AccountID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	bandFeeds := make([]map[string]any, 0, len(bands))
+	for _, band := range bands {
+		bandFeeds = append(bandFeeds, map[string]any{
+			"band_id": band.ID,
+			"name":    band.Name,
+			"url":     fmt.Sprintf("/calendar/%s/bands/%d.ics", calendarToken.Token, band.ID),
+		})
+	}
+
+	RespondWithJSON(w, status, map[string]any{
+		"url":        fmt.Sprintf("/calendar/%s.ics", calendarToken.Token),
+		"created_at": calendarToken.CreatedAt,
+		"bands":      bandFeeds,
+	})
+}
+
+// GetCalendar serves every show across the bands the token's owner belongs
+// to. Calendar apps can't log in, so the token in the URL is the credential.
+func (cfg *config) GetCalendar(w http.ResponseWriter, r *http.Request) {
+	calendarToken, ok := cfg.resolveCalendarToken(w, r, strings.TrimSuffix(r.PathValue("token"), ".ics"))
+	if !ok {
+		return
+	}
+
+	bands, err := cfg.db.GetAccountBands(r.Context(), calendarToken.AccountID)
+	if err != nil {
+		http.Error(w, "unexpected database error", http.StatusInternalServerError)
+		return
+	}
+
+	calendar := ical.Calendar{Name: "Shows"}
+	for _, band := range bands {
+		shows, err := cfg.db.GetBandShows(r.Context(), database.GetBandShowsParams{BandID: band.ID})
+		if err != nil {
+			http.Error(w, "unexpected database error", http.StatusInternalServerError)
+			return
+		}
+		for _, show := range shows {
+			calendar.Events = append(calendar.Events, showEvent(show, band.Name))
+		}
+	}
+	slices.SortFunc(calendar.Events, func(a, b ical.Event) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	respondWithCalendar(w, calendar)
+}
+
+func (cfg *config) GetBandCalendar(w http.ResponseWriter, r *http.Request) {
+	calendarToken, ok := cfg.resolveCalendarToken(w, r, r.PathValue("token"))
+	if !ok {
+		return
+	}
+
+	bandID, err := strconv.Atoi(strings.TrimSuffix(r.PathValue("band"), ".ics"))
+	if err != nil {
+		http.Error(w, "invalid band id", http.StatusBadRequest)
+		return
+	}
+
+	band, err := cfg.db.GetBand(r.Context(), database.GetBandParams{
+		AccountID: calendarToken.AccountID,
+		ID:        int32(bandID),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "no matching band", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, "unexpected database error", http.StatusInternalServerError)
+		return
+	}
+
+	shows, err := cfg.db.GetBandShows(r.Context(), database.GetBandShowsParams{BandID: band.ID})
+	if err != nil {
+		http.Error(w, "unexpected database error", http.StatusInternalServerError)
+		return
+	}
+
+	calendar := ical.Calendar{Name: fmt.Sprintf("%s shows", band.Name)}
+	for _, show := range shows {
+		calendar.Events = append(calendar.Events, showEvent(show, band.Name))
+	}
+
+	respondWithCalendar(w, calendar)
+}
+
+func (cfg *config) resolveCalendarToken(w http.ResponseWriter, r *http.Request, token string) (database.CalendarToken, bool) {
+	calendarToken, err := cfg.db.GetCalendarTokenByToken(r.Context(), token)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "this calendar feed is not valid", http.StatusNotFound)
+		return calendarToken, false
+	} else if err != nil {
+		http.Error(w, "unexpected database error", http.StatusInternalServerError)
+		return calendarToken, false
+	}
+	return calendarToken, true
+}
+
+func respondWithCalendar(w http.ResponseWriter, calendar ical.Calendar) {
+	var buf bytes.Buffer
+	err := calendar.Write(&buf)
+	if err != nil {
+		log.Printf("failed to write calendar: %v", err)
+		http.Error(w, "failed to write calendar", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// showEvent spans a show from the earliest scheduled time to the end of the
+// set, falling back to an all-day event when nothing has been scheduled yet.
+func showEvent(show database.Show, bandName string) ical.Event {
+	loc, err := time.LoadLocation(show.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	event := ical.Event{
+		UID:          fmt.Sprintf("show-%d@rider", show.ID),
+		Sequence:     int(show.Sequence),
+		Summary:      fmt.Sprintf("%s @ %s", bandName, show.VenueName),
+		Location:     strings.Trim(fmt.Sprintf("%s, %s", show.VenueName, show.City), ", "),
+		Status:       ical.StatusTentative,
+		Created:      show.CreatedAt,
+		LastModified: show.UpdatedAt,
+	}
+	switch show.Status {
+	case database.ShowStatusConfirmed:
+		event.Status = ical.StatusConfirmed
+	case database.ShowStatusCancelled:
+		event.Status = ical.StatusCancelled
+	}
+
+	schedule := []struct {
+		label string
+		at    sql.NullTime
+	}{
+		{"Load in", show.LoadInAt},
+		{"Soundcheck", show.SoundcheckAt},
+		{"Doors", show.DoorsAt},
+		{"Set", show.SetAt},
+	}
+	var lines []string
+	for _, item := range schedule {
+		if !item.at.Valid {
+			continue
+		}
+		t := inTimezone(item.at.Time, loc)
+		lines = append(lines, fmt.Sprintf("%s %s", item.label, t.Format("15:04")))
+		if event.Start.IsZero() || t.Before(event.Start) {
+			event.Start = t
+		}
+		if t.After(event.End) {
+			event.End = t
+		}
+	}
+	event.Description = strings.Join(lines, "\n")
+
+	if event.Start.IsZero() {
+		event.AllDay = true
+		event.Start = show.Date
+		event.End = show.Date.AddDate(0, 0, 1)
+	} else if show.SetAt.Valid {
+		// NOTE: we don't track set lengths yet, so assume a headline length set
+		setEnd := inTimezone(show.SetAt.Time, loc).Add(90 * time.Minute)
+		if setEnd.After(event.End) {
+			event.End = setEnd
+		}
+	} else {
+		event.End = event.End.Add(time.Hour)
+	}
+	return event
+}
+
+// inTimezone reads a stored wall clock time as a time in loc.
+func inTimezone(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+}
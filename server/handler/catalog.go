@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/jkellogg01/rider/server/catalog"
+)
+
+const (
+	defaultCatalogResults = 10
+	maxCatalogResults     = 50
+)
+
+// SearchCatalog backs autocomplete on input list and backline entries. It
+// takes the text typed so far as ?q= and optionally narrows it to a
+// ?category=.
+func (cfg *config) SearchCatalog(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	category := catalog.Category(query.Get("category"))
+	if category != "" && !slices.Contains(catalog.Categories, category) {
+		RespondWithError(w, http.StatusBadRequest, "category must be mic, di, amp, cab or console")
+		return
+	}
+
+	limit := defaultCatalogResults
+	if raw := query.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			RespondWithError(w, http.StatusBadRequest, "limit must be a positive number")
+			return
+		}
+		limit = min(n, maxCatalogResults)
+	}
+
+	RespondWithJSON(w, http.StatusOK, catalog.Search(query.Get("q"), category, limit))
+}
+
+func (cfg *config) GetCatalogItem(w http.ResponseWriter, r *http.Request) {
+	item, ok := catalog.Lookup(r.PathValue("item_id"))
+	if !ok {
+		RespondWithError(w, http.StatusNotFound, "no matching catalog item")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, item)
+}
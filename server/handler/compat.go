@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/jkellogg01/rider/server/compat"
+	"github.com/jkellogg01/rider/server/venue"
+)
+
+// GetShowCompatibility checks the show's effective rider against the
+// technical spec of the venue it's booked at.
+func (cfg *config) GetShowCompatibility(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	} else if !show.VenueID.Valid {
+		RespondWithError(w, http.StatusConflict, "this show isn't linked to a venue")
+		return
+	}
+
+	v, err := cfg.db.GetVenue(r.Context(), show.VenueID.Int32)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	spec, err := venue.Parse(v.Spec)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to decode venue spec")
+		return
+	}
+
+	revision, doc, ok := cfg.showRider(w, r, show)
+	if !ok {
+		return
+	}
+
+	report := compat.Check(doc, spec)
+	RespondWithJSON(w, http.StatusOK, map[string]any{
+		"show_id":         show.ID,
+		"venue_id":        v.ID,
+		"revision":        revision.Revision,
+		"ok":              report.OK(),
+		"blockers":        report.Blockers,
+		"warnings":        report.Warnings,
+		"rentals":         report.Rentals,
+		"planned_rentals": report.Planned,
+	})
+}
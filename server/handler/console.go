@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/jkellogg01/rider/server/console"
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/rider"
+)
+
+func (cfg *config) GetConsoles(w http.ResponseWriter, r *http.Request) {
+	res := []map[string]any{}
+	for _, id := range console.IDs() {
+		exporter, _ := console.Lookup(id)
+		res = append(res, map[string]any{
+			"id":        id,
+			"name":      exporter.Name(),
+			"extension": exporter.Extension(),
+		})
+	}
+	RespondWithJSON(w, http.StatusOK, res)
+}
+
+// GetRiderConsoleScene writes the latest revision of a rider's input list as
+// a scene file for the desk named by the console path value.
+func (cfg *config) GetRiderConsoleScene(w http.ResponseWriter, r *http.Request) {
+	rd, _, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	}
+
+	revision, doc, ok := cfg.latestRider(w, r, rd)
+	if !ok {
+		return
+	}
+
+	respondWithConsoleScene(w, r, rd.Name, revision, doc)
+}
+
+// GetShowConsoleScene writes a show's effective input list as a scene file
+// for the desk named by the console path value.
+func (cfg *config) GetShowConsoleScene(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	revision, doc, ok := cfg.showRider(w, r, show)
+	if !ok {
+		return
+	}
+
+	respondWithConsoleScene(w, r, show.VenueName, revision, doc)
+}
+
+// respondWithConsoleScene sends anything the desk couldn't take in the
+// X-Export-Warnings header, since the body has to be the scene file itself.
+func respondWithConsoleScene(w http.ResponseWriter, r *http.Request, name string, revision database.RiderRevision, doc rider.Document) {
+	exporter, ok := console.Lookup(r.PathValue("console"))
+	if !ok {
+		RespondWithError(w, http.StatusNotFound, fmt.Sprintf("no exporter for that desk, try one of: %s", strings.Join(console.IDs(), ", ")))
+		return
+	}
+
+	var buf bytes.Buffer
+	warnings, err := exporter.Export(&buf, doc.ConsoleScene(name))
+	if err != nil {
+		log.Printf("failed to export console scene: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to export console scene")
+		return
+	}
+
+	for _, warning := range warnings {
+		w.Header().Add("X-Export-Warnings", warning)
+	}
+	w.Header().Set("Content-Type", exporter.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("rider-r%d.%s", revision.Revision, exporter.Extension())))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,403 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/daysheet"
+	"github.com/jkellogg01/rider/server/venue"
+)
+
+var scheduleKindLabels = map[database.ScheduleKind]string{
+	database.ScheduleKindTravel:     "Travel",
+	database.ScheduleKindLoadIn:     "Load in",
+	database.ScheduleKindSoundcheck: "Soundcheck",
+	database.ScheduleKindDoors:      "Doors",
+	database.ScheduleKindSupport:    "Support",
+	database.ScheduleKindSet:        "Set",
+	database.ScheduleKindCurfew:     "Curfew",
+	database.ScheduleKindLoadOut:    "Load out",
+	database.ScheduleKindOther:      "Other",
+}
+
+type scheduleBody struct {
+	Kind     string `json:"kind"`
+	Label    string `json:"label"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Location string `json:"location"`
+	Notes    string `json:"notes"`
+}
+
+func (b scheduleBody) parse() (database.CreateScheduleItemParams, error) {
+	params := database.CreateScheduleItemParams{
+		Kind:     database.ScheduleKind(b.Kind),
+		Label:    strings.TrimSpace(b.Label),
+		Location: strings.TrimSpace(b.Location),
+		Notes:    b.Notes,
+	}
+	if _, ok := scheduleKindLabels[params.Kind]; !ok {
+		return params, fmt.Errorf("unknown schedule item kind %q", b.Kind)
+	} else if params.Kind == database.ScheduleKindOther && params.Label == "" {
+		return params, errors.New("other schedule items need a label")
+	}
+
+	start, err := time.Parse(wallClockLayout, b.Start)
+	if err != nil {
+		return params, fmt.Errorf("start must look like %s", wallClockLayout)
+	}
+	params.StartsAt = start
+
+	if b.End != "" {
+		end, err := time.Parse(wallClockLayout, b.End)
+		if err != nil {
+			return params, fmt.Errorf("end must look like %s", wallClockLayout)
+		} else if end.Before(start) {
+			return params, errors.New("schedule items can't end before they start")
+		}
+		params.EndsAt = sql.NullTime{Time: end, Valid: true}
+	}
+	return params, nil
+}
+
+func (cfg *config) GetShowSchedule(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	items, err := cfg.db.GetShowScheduleItems(r.Context(), show.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	res := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		res = append(res, scheduleItemResponse(item))
+	}
+	RespondWithJSON(w, http.StatusOK, res)
+}
+
+func (cfg *config) CreateScheduleItem(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	var body scheduleBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	params, err := body.parse()
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	params.ShowID = show.ID
+
+	item, err := cfg.db.CreateScheduleItem(r.Context(), params)
+	if err != nil {
+		log.Printf("failed to create schedule item: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, scheduleItemResponse(item))
+}
+
+func (cfg *config) UpdateScheduleItem(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	itemID, err := strconv.Atoi(r.PathValue("item_id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "invalid schedule item id")
+		return
+	}
+
+	var body scheduleBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	params, err := body.parse()
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	item, err := cfg.db.UpdateScheduleItem(r.Context(), database.UpdateScheduleItemParams{
+		ID:       int32(itemID),
+		ShowID:   show.ID,
+		Kind:     params.Kind,
+		Label:    params.Label,
+		StartsAt: params.StartsAt,
+		EndsAt:   params.EndsAt,
+		Location: params.Location,
+		Notes:    params.Notes,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusNotFound, "no matching schedule item")
+		return
+	} else if err != nil {
+		log.Printf("failed to update schedule item: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, scheduleItemResponse(item))
+}
+
+func (cfg *config) DeleteScheduleItem(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	itemID, err := strconv.Atoi(r.PathValue("item_id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "invalid schedule item id")
+		return
+	}
+
+	deleted, err := cfg.db.DeleteScheduleItem(r.Context(), database.DeleteScheduleItemParams{
+		ID:     int32(itemID),
+		ShowID: show.ID,
+	})
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	} else if deleted == 0 {
+		RespondWithError(w, http.StatusNotFound, "no matching schedule item")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateDaySheetNotes sets the parts of a day sheet that aren't on the
+// schedule or the venue's spec.
+func (cfg *config) UpdateDaySheetNotes(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Parking string `json:"parking"`
+		WiFi    string `json:"wifi"`
+		Notes   string `json:"notes"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	updated, err := cfg.db.UpdateShowDaySheetNotes(r.Context(), database.UpdateShowDaySheetNotesParams{
+		ID:           show.ID,
+		ParkingNotes: strings.TrimSpace(body.Parking),
+		WifiNotes:    strings.TrimSpace(body.WiFi),
+		DayNotes:     strings.TrimSpace(body.Notes),
+	})
+	if err != nil {
+		log.Printf("failed to update day sheet notes: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, showResponse(updated))
+}
+
+// GetDaySheet puts together the show's schedule, the venue's address and
+// contacts and the day's notes, as JSON, a PDF or plain text picked with
+// ?format=.
+func (cfg *config) GetDaySheet(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "":
+		format = "json"
+	case "txt":
+		format = "text"
+	case "json", "pdf", "text":
+	default:
+		RespondWithError(w, http.StatusBadRequest, "format must be json, pdf or text")
+		return
+	}
+
+	sheet, err := cfg.daySheet(r, show)
+	if err != nil {
+		log.Printf("failed to build day sheet for show %d: %v", show.ID, err)
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	filename := fmt.Sprintf("day-sheet-%s", show.Date.Format(dateLayout))
+	var buf bytes.Buffer
+	switch format {
+	case "json":
+		RespondWithJSON(w, http.StatusOK, map[string]any{
+			"show_id":  show.ID,
+			"title":    sheet.Title,
+			"date":     show.Date.Format(dateLayout),
+			"timezone": show.Timezone,
+			"venue":    sheet.Venue,
+			"address":  sheet.Address,
+			"schedule": sheet.Schedule(),
+			"contacts": sheet.Contacts,
+			"parking":  sheet.Parking,
+			"wifi":     sheet.WiFi,
+			"notes":    sheet.Notes,
+		})
+		return
+	case "pdf":
+		err = sheet.WritePDF(&buf)
+		w.Header().Set("Content-Type", "application/pdf")
+		filename += ".pdf"
+	case "text":
+		err = sheet.WriteText(&buf)
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		filename += ".txt"
+	}
+	if err != nil {
+		log.Printf("failed to render day sheet: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to render day sheet")
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// daySheet merges the show's own schedule times with its schedule items. A
+// show time is left out when an item of the same kind already covers it.
+func (cfg *config) daySheet(r *http.Request, show database.Show) (daysheet.Sheet, error) {
+	loc, err := time.LoadLocation(show.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	band, err := cfg.db.GetBandByID(r.Context(), show.BandID)
+	if err != nil {
+		return daysheet.Sheet{}, err
+	}
+
+	sheet := daysheet.Sheet{
+		Title:    fmt.Sprintf("%s @ %s", band.Name, show.VenueName),
+		Date:     show.Date,
+		Location: loc,
+		Venue:    show.VenueName,
+		Address:  show.City,
+		Contacts: []daysheet.Contact{},
+		Parking:  show.ParkingNotes,
+		WiFi:     show.WifiNotes,
+		Notes:    show.DayNotes,
+	}
+
+	if show.VenueID.Valid {
+		v, err := cfg.db.GetVenue(r.Context(), show.VenueID.Int32)
+		if err != nil {
+			return sheet, err
+		}
+		spec, err := venue.Parse(v.Spec)
+		if err != nil {
+			return sheet, err
+		}
+		sheet.Address = strings.Trim(fmt.Sprintf("%s, %s", v.Address, v.City), ", ")
+		for _, c := range spec.Contacts {
+			sheet.Contacts = append(sheet.Contacts, daysheet.Contact{Name: c.Name, Role: c.Role, Phone: c.Phone, Email: c.Email})
+		}
+	}
+
+	items, err := cfg.db.GetShowScheduleItems(r.Context(), show.ID)
+	if err != nil {
+		return sheet, err
+	}
+	covered := map[database.ScheduleKind]bool{}
+	for _, item := range items {
+		covered[item.Kind] = true
+		entry := daysheet.Item{
+			Label:    scheduleItemLabel(item),
+			Start:    inTimezone(item.StartsAt, loc),
+			Location: item.Location,
+			Notes:    item.Notes,
+		}
+		if item.EndsAt.Valid {
+			end := inTimezone(item.EndsAt.Time, loc)
+			entry.End = &end
+		}
+		sheet.Items = append(sheet.Items, entry)
+	}
+
+	showTimes := []struct {
+		kind database.ScheduleKind
+		at   sql.NullTime
+	}{
+		{database.ScheduleKindLoadIn, show.LoadInAt},
+		{database.ScheduleKindSoundcheck, show.SoundcheckAt},
+		{database.ScheduleKindDoors, show.DoorsAt},
+		{database.ScheduleKindSet, show.SetAt},
+	}
+	for _, t := range showTimes {
+		if t.at.Valid && !covered[t.kind] {
+			sheet.Items = append(sheet.Items, daysheet.Item{
+				Label: scheduleKindLabels[t.kind],
+				Start: inTimezone(t.at.Time, loc),
+			})
+		}
+	}
+	return sheet, nil
+}
+
+func scheduleItemLabel(item database.ScheduleItem) string {
+	if item.Label == "" {
+		return scheduleKindLabels[item.Kind]
+	} else if item.Kind == database.ScheduleKindOther {
+		return item.Label
+	}
+	return fmt.Sprintf("%s: %s", scheduleKindLabels[item.Kind], item.Label)
+}
+
+func scheduleItemResponse(item database.ScheduleItem) map[string]any {
+	var end *string
+	if item.EndsAt.Valid {
+		s := item.EndsAt.Time.Format(wallClockLayout)
+		end = &s
+	}
+
+	return map[string]any{
+		"id":         item.ID,
+		"show_id":    item.ShowID,
+		"kind":       item.Kind,
+		"label":      item.Label,
+		"start":      item.StartsAt.Format(wallClockLayout),
+		"end":        end,
+		"location":   item.Location,
+		"notes":      item.Notes,
+		"created_at": item.CreatedAt,
+		"updated_at": item.UpdatedAt,
+	}
+}
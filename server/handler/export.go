@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/rider"
+	"github.com/jkellogg01/rider/server/xlsx"
+)
+
+// exportFormats maps each ?format= value to the media type that asks for it
+// in an Accept header.
+var exportFormats = map[string]string{
+	"json":     "application/json",
+	"csv":      "text/csv",
+	"xlsx":     xlsxMediaType,
+	"markdown": "text/markdown",
+	"text":     "text/plain",
+}
+
+// exportFormat picks a format from ?format=, then from the Accept header in
+// the order the client listed it, falling back to JSON.
+func exportFormat(r *http.Request) (string, bool) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		switch format {
+		case "md":
+			format = "markdown"
+		case "txt":
+			format = "text"
+		}
+		_, ok := exportFormats[format]
+		return format, ok
+	}
+
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		for format, t := range exportFormats {
+			if mediaType == t {
+				return format, true
+			}
+		}
+	}
+	return "json", true
+}
+
+// GetRiderInputList exports the input list and monitor matrix from the latest
+// revision of a rider.
+func (cfg *config) GetRiderInputList(w http.ResponseWriter, r *http.Request) {
+	rd, _, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	}
+
+	revision, doc, ok := cfg.latestRider(w, r, rd)
+	if !ok {
+		return
+	}
+
+	respondWithInputList(w, r, rd.Name, revision, doc)
+}
+
+// GetShowInputList exports the input list and monitor matrix from a show's
+// effective rider.
+func (cfg *config) GetShowInputList(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	revision, doc, ok := cfg.showRider(w, r, show)
+	if !ok {
+		return
+	}
+
+	respondWithInputList(w, r, showRiderTitle(show), revision, doc)
+}
+
+func respondWithInputList(w http.ResponseWriter, r *http.Request, name string, revision database.RiderRevision, doc rider.Document) {
+	tables := doc.InputListTables()
+	// a CSV file only holds the first table, so the matrix is opt in
+	if r.URL.Query().Get("table") == "monitors" {
+		if len(tables) < 2 {
+			RespondWithError(w, http.StatusNotFound, "this rider has no monitor mixes")
+			return
+		}
+		tables = tables[1:]
+	}
+
+	headers, rows := doc.MonitorMatrix()
+	respondWithTables(w, r, fmt.Sprintf("input-list-r%d", revision.Revision), tables, map[string]any{
+		"name":       name,
+		"revision":   revision.Revision,
+		"input_list": doc.InputList,
+		"monitor_matrix": map[string]any{
+			"headers": headers,
+			"rows":    rows,
+		},
+	})
+}
+
+// respondWithTables writes tables in the format the request asks for, or
+// responds with data when it asks for JSON. CSV only holds the first table.
+func respondWithTables(w http.ResponseWriter, r *http.Request, filename string, tables []rider.Table, data any) {
+	format, ok := exportFormat(r)
+	if !ok {
+		RespondWithError(w, http.StatusBadRequest, "format must be json, csv, xlsx, markdown or text")
+		return
+	}
+
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case "json":
+		RespondWithJSON(w, http.StatusOK, data)
+		return
+	case "csv":
+		respondWithCSV(w, filename+".csv", tables[0].Headers, tables[0].Rows)
+		return
+	case "xlsx":
+		sheets := make([]xlsx.Sheet, len(tables))
+		for i, table := range tables {
+			sheets[i] = xlsx.Sheet{Name: table.Title, Headers: table.Headers, Rows: table.Rows}
+		}
+		err = xlsx.Write(&buf, sheets)
+		filename += ".xlsx"
+	case "markdown":
+		err = rider.WriteMarkdown(&buf, tables)
+		filename += ".md"
+	case "text":
+		err = rider.WriteText(&buf, tables)
+		filename += ".txt"
+	}
+	if err != nil {
+		log.Printf("failed to export %s: %v", filename, err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to export")
+		return
+	}
+
+	contentType := exportFormats[format]
+	if strings.HasPrefix(contentType, "text/") {
+		contentType += "; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
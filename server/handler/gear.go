@@ -0,0 +1,448 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/rider"
+)
+
+const maxGearPhotoBytes = 10 << 20
+
+var gearPhotoTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+type gearBody struct {
+	BandID int32 `json:"band_id"`
+	// OwnerID is the member who owns the item, or zero for band gear.
+	OwnerID      int32   `json:"owner_id"`
+	Name         string  `json:"name"`
+	Category     string  `json:"category"`
+	Make         string  `json:"make"`
+	Model        string  `json:"model"`
+	SerialNumber string  `json:"serial_number"`
+	WeightKg     float64 `json:"weight_kg"`
+	WidthCm      float64 `json:"width_cm"`
+	DepthCm      float64 `json:"depth_cm"`
+	HeightCm     float64 `json:"height_cm"`
+	PowerWatts   int32   `json:"power_watts"`
+	ValueCents   int32   `json:"value_cents"`
+	Currency     string  `json:"currency"`
+	Notes        string  `json:"notes"`
+	// CaseName is the road case the item travels in.
+	CaseName string `json:"case_name"`
+	// CountryOfOrigin is where the item was made, for customs.
+	CountryOfOrigin string `json:"country_of_origin"`
+}
+
+func (b gearBody) parse() (database.CreateGearParams, error) {
+	params := database.CreateGearParams{
+		BandID:          b.BandID,
+		Name:            strings.TrimSpace(b.Name),
+		Category:        strings.TrimSpace(b.Category),
+		Make:            strings.TrimSpace(b.Make),
+		Model:           strings.TrimSpace(b.Model),
+		SerialNumber:    strings.TrimSpace(b.SerialNumber),
+		WeightKg:        b.WeightKg,
+		WidthCm:         b.WidthCm,
+		DepthCm:         b.DepthCm,
+		HeightCm:        b.HeightCm,
+		PowerWatts:      b.PowerWatts,
+		ValueCents:      b.ValueCents,
+		Currency:        strings.ToUpper(strings.TrimSpace(b.Currency)),
+		Notes:           b.Notes,
+		CaseName:        strings.TrimSpace(b.CaseName),
+		CountryOfOrigin: strings.TrimSpace(b.CountryOfOrigin),
+	}
+	if params.Name == "" {
+		return params, errors.New("gear name is required")
+	} else if b.WeightKg < 0 || b.WidthCm < 0 || b.DepthCm < 0 || b.HeightCm < 0 || b.PowerWatts < 0 || b.ValueCents < 0 {
+		return params, errors.New("weights, sizes, power and value can't be negative")
+	}
+	if params.Currency == "" {
+		params.Currency = "USD"
+	} else if len(params.Currency) != 3 {
+		return params, fmt.Errorf("currency must be a three letter code, got %q", b.Currency)
+	}
+	if b.OwnerID != 0 {
+		params.OwnerID = sql.NullInt32{Int32: b.OwnerID, Valid: true}
+	}
+	return params, nil
+}
+
+// checkGearOwner makes sure gear is only ever owned by members of the band.
+func (cfg *config) checkGearOwner(w http.ResponseWriter, r *http.Request, bandID int32, ownerID sql.NullInt32) bool {
+	if !ownerID.Valid {
+		return true
+	}
+
+	_, err := cfg.db.GetAccountBand(r.Context(), database.GetAccountBandParams{
+		AccountID: ownerID.Int32,
+		BandID:    bandID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusBadRequest, "gear can only be owned by a member of the band")
+		return false
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return false
+	}
+	return true
+}
+
+func (cfg *config) CreateGear(w http.ResponseWriter, r *http.Request) {
+	var body gearBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	params, err := body.parse()
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, ok := cfg.membership(w, r, params.BandID)
+	if !ok {
+		return
+	} else if !cfg.checkGearOwner(w, r, params.BandID, params.OwnerID) {
+		return
+	}
+
+	gear, err := cfg.db.CreateGear(r.Context(), params)
+	if err != nil {
+		log.Printf("failed to create gear: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, gearResponse(gear, nil))
+}
+
+func (cfg *config) GetBandGear(w http.ResponseWriter, r *http.Request) {
+	membership, ok := cfg.bandAccess(w, r)
+	if !ok {
+		return
+	}
+
+	gear, err := cfg.db.GetBandGear(r.Context(), membership.BandID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	res := make([]map[string]any, 0, len(gear))
+	for _, item := range gear {
+		res = append(res, gearResponse(item, nil))
+	}
+	RespondWithJSON(w, http.StatusOK, res)
+}
+
+func (cfg *config) GetGear(w http.ResponseWriter, r *http.Request) {
+	gear, _, ok := cfg.gearAccess(w, r)
+	if !ok {
+		return
+	}
+
+	photos, err := cfg.db.GetGearPhotos(r.Context(), gear.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	} else if photos == nil {
+		photos = []database.GetGearPhotosRow{}
+	}
+
+	RespondWithJSON(w, http.StatusOK, gearResponse(gear, photos))
+}
+
+func (cfg *config) UpdateGear(w http.ResponseWriter, r *http.Request) {
+	gear, _, ok := cfg.gearAccess(w, r)
+	if !ok {
+		return
+	}
+
+	var body gearBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+	// gear can't be moved between bands
+	body.BandID = gear.BandID
+
+	params, err := body.parse()
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if !cfg.checkGearOwner(w, r, gear.BandID, params.OwnerID) {
+		return
+	}
+
+	updated, err := cfg.db.UpdateGear(r.Context(), database.UpdateGearParams{
+		ID:              gear.ID,
+		OwnerID:         params.OwnerID,
+		Name:            params.Name,
+		Category:        params.Category,
+		Make:            params.Make,
+		Model:           params.Model,
+		SerialNumber:    params.SerialNumber,
+		WeightKg:        params.WeightKg,
+		WidthCm:         params.WidthCm,
+		DepthCm:         params.DepthCm,
+		HeightCm:        params.HeightCm,
+		PowerWatts:      params.PowerWatts,
+		ValueCents:      params.ValueCents,
+		Currency:        params.Currency,
+		Notes:           params.Notes,
+		CaseName:        params.CaseName,
+		CountryOfOrigin: params.CountryOfOrigin,
+	})
+	if err != nil {
+		log.Printf("failed to update gear: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, gearResponse(updated, nil))
+}
+
+// DeleteGear removes an item from the inventory. Riders that still refer to
+// it keep whatever they wrote in themselves, and can still be saved with the
+// reference in place.
+func (cfg *config) DeleteGear(w http.ResponseWriter, r *http.Request) {
+	gear, membership, ok := cfg.gearAccess(w, r)
+	if !ok {
+		return
+	}
+
+	isOwner := gear.OwnerID.Valid && gear.OwnerID.Int32 == membership.AccountID
+	if !membership.AccountIsAdmin && !isOwner {
+		RespondWithError(w, http.StatusForbidden, "only band admins or the owner can delete gear")
+		return
+	}
+
+	err := cfg.db.DeleteGear(r.Context(), gear.ID)
+	if err != nil {
+		log.Printf("failed to delete gear: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddGearPhoto stores the image sent as the request body.
+func (cfg *config) AddGearPhoto(w http.ResponseWriter, r *http.Request) {
+	gear, _, ok := cfg.gearAccess(w, r)
+	if !ok {
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxGearPhotoBytes))
+	if err != nil {
+		RespondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("photos can be at most %d MB", maxGearPhotoBytes>>20))
+		return
+	}
+
+	contentType := http.DetectContentType(data)
+	supported := false
+	for _, t := range gearPhotoTypes {
+		supported = supported || contentType == t
+	}
+	if !supported {
+		RespondWithError(w, http.StatusUnsupportedMediaType, "photos must be JPEG, PNG, GIF or WebP images")
+		return
+	}
+
+	photo, err := cfg.db.CreateGearPhoto(r.Context(), database.CreateGearPhotoParams{
+		GearID:      gear.ID,
+		ContentType: contentType,
+		Data:        data,
+	})
+	if err != nil {
+		log.Printf("failed to store gear photo: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, gearPhotoResponse(photo.ID, photo.GearID, photo.ContentType))
+}
+
+func (cfg *config) GetGearPhoto(w http.ResponseWriter, r *http.Request) {
+	gear, _, ok := cfg.gearAccess(w, r)
+	if !ok {
+		return
+	}
+
+	photoID, err := strconv.Atoi(r.PathValue("photo_id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "invalid photo id")
+		return
+	}
+
+	photo, err := cfg.db.GetGearPhoto(r.Context(), database.GetGearPhotoParams{
+		ID:     int32(photoID),
+		GearID: gear.ID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusNotFound, "no matching photo")
+		return
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	w.Header().Set("Content-Type", photo.ContentType)
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.WriteHeader(http.StatusOK)
+	w.Write(photo.Data)
+}
+
+func (cfg *config) DeleteGearPhoto(w http.ResponseWriter, r *http.Request) {
+	gear, _, ok := cfg.gearAccess(w, r)
+	if !ok {
+		return
+	}
+
+	photoID, err := strconv.Atoi(r.PathValue("photo_id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "invalid photo id")
+		return
+	}
+
+	deleted, err := cfg.db.DeleteGearPhoto(r.Context(), database.DeleteGearPhotoParams{
+		ID:     int32(photoID),
+		GearID: gear.ID,
+	})
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	} else if deleted == 0 {
+		RespondWithError(w, http.StatusNotFound, "no matching photo")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// bandGear looks up the band's inventory for filling in and checking rider
+// documents.
+func (cfg *config) bandGear(ctx context.Context, bandID int32) (map[int32]rider.Gear, error) {
+	items, err := cfg.db.GetBandGear(ctx, bandID)
+	if err != nil {
+		return nil, err
+	}
+
+	gear := make(map[int32]rider.Gear, len(items))
+	for _, item := range items {
+		gear[item.ID] = rider.Gear{
+			Name:  item.Name,
+			Make:  item.Make,
+			Model: item.Model,
+			Watts: int(item.PowerWatts),
+		}
+	}
+	return gear, nil
+}
+
+// checkGearRefs makes sure a rider only refers to the band's own inventory.
+// Items already referred to by the documents being replaced are let through
+// even once they're deleted, so a band isn't stopped from saving until it
+// tracks them down.
+func (cfg *config) checkGearRefs(w http.ResponseWriter, r *http.Request, bandID int32, doc rider.Document, replaced ...rider.Document) bool {
+	ids := doc.GearIDs()
+	for _, previous := range replaced {
+		known := previous.GearIDs()
+		ids = slices.DeleteFunc(ids, func(id int32) bool {
+			_, found := slices.BinarySearch(known, id)
+			return found
+		})
+	}
+	if len(ids) == 0 {
+		return true
+	}
+
+	gear, err := cfg.bandGear(r.Context(), bandID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return false
+	}
+	for _, id := range ids {
+		if _, ok := gear[id]; !ok {
+			RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("gear %d is not in the band's inventory", id))
+			return false
+		}
+	}
+	return true
+}
+
+// fillGear fills in rider lines from the inventory items they refer to. Like
+// member dietary restrictions, it happens when a rider is read out.
+func (cfg *config) fillGear(ctx context.Context, bandID int32, doc rider.Document) (rider.Document, error) {
+	if len(doc.GearIDs()) == 0 {
+		return doc, nil
+	}
+
+	gear, err := cfg.bandGear(ctx, bandID)
+	if err != nil {
+		return doc, err
+	}
+	return doc.WithGear(gear), nil
+}
+
+func gearResponse(gear database.Gear, photos []database.GetGearPhotosRow) map[string]any {
+	var ownerID *int32
+	if gear.OwnerID.Valid {
+		ownerID = &gear.OwnerID.Int32
+	}
+
+	res := map[string]any{
+		"id":                gear.ID,
+		"band_id":           gear.BandID,
+		"owner_id":          ownerID,
+		"name":              gear.Name,
+		"category":          gear.Category,
+		"make":              gear.Make,
+		"model":             gear.Model,
+		"serial_number":     gear.SerialNumber,
+		"weight_kg":         gear.WeightKg,
+		"width_cm":          gear.WidthCm,
+		"depth_cm":          gear.DepthCm,
+		"height_cm":         gear.HeightCm,
+		"power_watts":       gear.PowerWatts,
+		"value_cents":       gear.ValueCents,
+		"currency":          gear.Currency,
+		"notes":             gear.Notes,
+		"case_name":         gear.CaseName,
+		"country_of_origin": gear.CountryOfOrigin,
+		"created_at":        gear.CreatedAt,
+		"updated_at":        gear.UpdatedAt,
+	}
+	if photos != nil {
+		list := make([]map[string]any, 0, len(photos))
+		for _, photo := range photos {
+			list = append(list, gearPhotoResponse(photo.ID, photo.GearID, photo.ContentType))
+		}
+		res["photos"] = list
+	}
+	return res
+}
+
+func gearPhotoResponse(id, gearID int32, contentType string) map[string]any {
+	return map[string]any{
+		"id":           id,
+		"content_type": contentType,
+		"url":          fmt.Sprintf("/api/gear/%d/photos/%d", gearID, id),
+	}
+}
@@ -9,7 +9,8 @@ import (
 )
 
 type config struct {
-	db *database.Queries
+	conn *sql.DB
+	db   *database.Queries
 }
 
 func NewConfig() *config {
@@ -17,6 +18,7 @@ func NewConfig() *config {
 }
 
 func (cfg *config) WithDB(db *sql.DB) *config {
+	cfg.conn = db
 	cfg.db = database.New(db)
 	return cfg
 }
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jkellogg01/rider/server/rider"
+	"github.com/jkellogg01/rider/server/xlsx"
+)
+
+const (
+	maxImportBytes = 5 << 20
+	xlsxMediaType  = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// ImportInputList reads an input list from a CSV or XLSX file sent as the
+// request body. With ?dry_run=true it only reports what it found, so the band
+// can check the detected columns and fix any rows first, along with any
+// document_error the new channels would cause in the rest of the rider, such
+// as a duplicate channel number or a mix fed by a dropped channel. Otherwise the
+// channels replace the rider's input list, or with ?mode=append are added to
+// it, in a single transaction.
+func (cfg *config) ImportInputList(w http.ResponseWriter, r *http.Request) {
+	rd, membership, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	dryRun, _ := strconv.ParseBool(query.Get("dry_run"))
+	mode := query.Get("mode")
+	if mode == "" {
+		mode = "replace"
+	} else if mode != "replace" && mode != "append" {
+		RespondWithError(w, http.StatusBadRequest, "mode must be replace or append")
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportBytes))
+	if err != nil {
+		RespondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("imports can be at most %d MB", maxImportBytes>>20))
+		return
+	}
+
+	rows, err := readSpreadsheet(data, query.Get("format"), r.Header.Get("Content-Type"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result := rider.ImportInputList(rows)
+	if dryRun {
+		latest, err := cfg.db.GetLatestRiderRevision(r.Context(), rd.ID)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+			return
+		}
+		doc, err := rider.Parse(latest.Document)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "failed to decode rider document")
+			return
+		}
+
+		// the rest of the rider has to agree with the new channels too, the
+		// same as when the import is saved
+		var documentError *string
+		err = mergeInputList(doc, result.Channels, mode).WithLineIDs().Validate()
+		if err != nil {
+			message := err.Error()
+			documentError = &message
+		}
+		RespondWithJSON(w, http.StatusOK, map[string]any{
+			"import":         result,
+			"document_error": documentError,
+			"committed":      false,
+		})
+		return
+	} else if len(result.Errors) > 0 {
+		RespondWithJSON(w, http.StatusUnprocessableEntity, map[string]any{
+			"message":   "fix the rows with errors and try again",
+			"import":    result,
+			"committed": false,
+		})
+		return
+	}
+
+	tx, err := cfg.conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	latest, err := qtx.GetLatestRiderRevision(r.Context(), rd.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	doc, err := rider.Parse(latest.Document)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to decode rider document")
+		return
+	}
+	document, ok := encodeDocument(w, mergeInputList(doc, result.Channels, mode))
+	if !ok {
+		return
+	}
+
+	revision, _, err := saveRiderDocument(r.Context(), qtx, latest, membership.AccountID, document)
+	if err != nil {
+		log.Printf("failed to save imported input list: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]any{
+		"import":    result,
+		"committed": true,
+		"revision":  revision,
+	})
+}
+
+// mergeInputList puts imported channels on the rider, replacing its input list
+// or adding to the end of it.
+func mergeInputList(doc rider.Document, channels []rider.Channel, mode string) rider.Document {
+	if mode == "append" {
+		doc.InputList = append(slices.Clone(doc.InputList), channels...)
+	} else {
+		doc.InputList = channels
+	}
+	return doc
+}
+
+// readSpreadsheet parses an uploaded CSV or XLSX file. The format comes from
+// the format query parameter, then the content type, and finally from
+// sniffing the file, since browsers don't agree on a type for CSV.
+func readSpreadsheet(data []byte, format, contentType string) ([][]string, error) {
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		switch {
+		case mediaType == xlsxMediaType:
+			format = "xlsx"
+		case mediaType == "text/csv":
+			format = "csv"
+		case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+			format = "xlsx"
+		default:
+			format = "csv"
+		}
+	}
+
+	switch format {
+	case "xlsx":
+		rows, err := xlsx.Read(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read spreadsheet: %w", err)
+		}
+		return rows, nil
+	case "csv":
+		return readCSV(data)
+	}
+	return nil, errors.New("format must be csv or xlsx")
+}
+
+// readCSV reads comma, semicolon or tab separated files, going by whichever
+// separator the first line has most of.
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	firstLine, _, _ := strings.Cut(string(data), "\n")
+	comma := ','
+	for _, candidate := range []rune{';', '\t'} {
+		if strings.Count(firstLine, string(candidate)) > strings.Count(firstLine, string(comma)) {
+			comma = candidate
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/pdf"
+)
+
+type manifestCase struct {
+	Case     string  `json:"case"`
+	Items    int     `json:"items"`
+	WeightKg float64 `json:"weight_kg"`
+	VolumeM3 float64 `json:"volume_m3"`
+}
+
+type manifestTotals struct {
+	// Pieces counts each case once and each loose item on its own, which
+	// is how airlines and freight forwarders count.
+	Pieces   int              `json:"pieces"`
+	Cases    int              `json:"cases"`
+	WeightKg float64          `json:"weight_kg"`
+	VolumeM3 float64          `json:"volume_m3"`
+	Value    map[string]int64 `json:"value_cents"`
+	// Unlisted names packing list items that aren't in the gear inventory,
+	// so have no weight, size or value to count.
+	Unlisted []string `json:"unlisted"`
+}
+
+func manifestFor(lines []packingLine) ([]manifestCase, manifestTotals) {
+	totals := manifestTotals{Value: map[string]int64{}, Unlisted: []string{}}
+	cases := []manifestCase{}
+	for _, line := range lines {
+		if line.Case == looseCase {
+			totals.Pieces += line.Quantity
+		} else if len(cases) == 0 || cases[len(cases)-1].Case != line.Case {
+			cases = append(cases, manifestCase{Case: line.Case})
+			totals.Pieces++
+			totals.Cases++
+		}
+
+		if line.gear == nil {
+			totals.Unlisted = append(totals.Unlisted, line.Name)
+			continue
+		}
+		weight := line.gear.WeightKg * float64(line.Quantity)
+		volume := line.gear.WidthCm * line.gear.DepthCm * line.gear.HeightCm / 1e6 * float64(line.Quantity)
+		totals.WeightKg += weight
+		totals.VolumeM3 += volume
+		totals.Value[line.gear.Currency] += int64(line.gear.ValueCents) * int64(line.Quantity)
+		if line.Case != looseCase {
+			c := &cases[len(cases)-1]
+			c.Items += line.Quantity
+			c.WeightKg = roundTo(c.WeightKg+weight, 2)
+			c.VolumeM3 = roundTo(c.VolumeM3+volume, 3)
+		}
+	}
+	totals.WeightKg = roundTo(totals.WeightKg, 2)
+	totals.VolumeM3 = roundTo(totals.VolumeM3, 3)
+	return cases, totals
+}
+
+func roundTo(f float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(f*scale) / scale
+}
+
+// GetShowManifest sums up the weight, volume and piece count of everything on
+// a show's packing list for the cargo manifest. Pass ?format=csv or
+// ?format=pdf for a document rather than JSON.
+func (cfg *config) GetShowManifest(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	lines, ok := cfg.packingList(w, r, show)
+	if !ok {
+		return
+	}
+	cases, totals := manifestFor(lines)
+
+	headers := []string{"Piece", "Items", "Weight (kg)", "Volume (m3)"}
+	var rows [][]string
+	for _, c := range cases {
+		rows = append(rows, []string{c.Case, strconv.Itoa(c.Items), formatFloat(c.WeightKg), formatFloat(c.VolumeM3)})
+	}
+	for _, line := range lines {
+		if line.Case != looseCase {
+			continue
+		}
+		weight, volume := "", ""
+		if line.gear != nil {
+			weight = formatFloat(line.gear.WeightKg * float64(line.Quantity))
+			volume = formatFloat(roundTo(line.gear.WidthCm*line.gear.DepthCm*line.gear.HeightCm/1e6*float64(line.Quantity), 3))
+		}
+		rows = append(rows, []string{line.Name, strconv.Itoa(line.Quantity), weight, volume})
+	}
+	rows = append(rows, []string{"Total", strconv.Itoa(totals.Pieces) + " pieces", formatFloat(totals.WeightKg), formatFloat(totals.VolumeM3)})
+
+	title := fmt.Sprintf("Cargo manifest - %s", showRiderTitle(show))
+	switch r.URL.Query().Get("format") {
+	case "":
+		RespondWithJSON(w, http.StatusOK, map[string]any{
+			"show_id": show.ID,
+			"cases":   cases,
+			"totals":  totals,
+		})
+	case "csv":
+		respondWithCSV(w, fmt.Sprintf("manifest-show-%d.csv", show.ID), headers, rows)
+	case "pdf":
+		var notes []string
+		if len(totals.Unlisted) > 0 {
+			notes = append(notes, fmt.Sprintf("Not in the gear inventory, so not weighed: %d item(s)", len(totals.Unlisted)))
+		}
+		respondWithTablePDF(w, fmt.Sprintf("manifest-show-%d.pdf", show.ID), title, notes, headers, rows)
+	default:
+		RespondWithError(w, http.StatusBadRequest, "format must be csv or pdf")
+	}
+}
+
+// GetShowCarnet itemizes everything on a show's packing list the way customs
+// and ATA carnets want it, with serials, countries of origin and values.
+func (cfg *config) GetShowCarnet(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	lines, ok := cfg.packingList(w, r, show)
+	if !ok {
+		return
+	}
+
+	type carnetItem struct {
+		Number          int     `json:"number"`
+		Description     string  `json:"description"`
+		SerialNumber    string  `json:"serial_number"`
+		Quantity        int     `json:"quantity"`
+		WeightKg        float64 `json:"weight_kg"`
+		ValueCents      int64   `json:"value_cents"`
+		Currency        string  `json:"currency"`
+		CountryOfOrigin string  `json:"country_of_origin"`
+		Case            string  `json:"case"`
+	}
+	items := make([]carnetItem, 0, len(lines))
+	for i, line := range lines {
+		item := carnetItem{
+			Number:      i + 1,
+			Description: line.Name,
+			Quantity:    line.Quantity,
+			Case:        line.Case,
+		}
+		if g := line.gear; g != nil {
+			item.Description = carnetDescription(*g)
+			item.SerialNumber = g.SerialNumber
+			item.WeightKg = roundTo(g.WeightKg*float64(line.Quantity), 2)
+			item.ValueCents = int64(g.ValueCents) * int64(line.Quantity)
+			item.Currency = g.Currency
+			item.CountryOfOrigin = g.CountryOfOrigin
+		}
+		items = append(items, item)
+	}
+
+	headers := []string{"No.", "Description", "Serial", "Qty", "Weight (kg)", "Value", "Origin", "Case"}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		value := ""
+		if item.Currency != "" {
+			value = fmt.Sprintf("%d.%02d %s", item.ValueCents/100, item.ValueCents%100, item.Currency)
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(item.Number),
+			item.Description,
+			item.SerialNumber,
+			strconv.Itoa(item.Quantity),
+			formatFloat(item.WeightKg),
+			value,
+			item.CountryOfOrigin,
+			item.Case,
+		})
+	}
+
+	switch r.URL.Query().Get("format") {
+	case "":
+		RespondWithJSON(w, http.StatusOK, map[string]any{
+			"show_id": show.ID,
+			"items":   items,
+		})
+	case "csv":
+		respondWithCSV(w, fmt.Sprintf("carnet-show-%d.csv", show.ID), headers, rows)
+	case "pdf":
+		title := fmt.Sprintf("General list - %s", showRiderTitle(show))
+		respondWithTablePDF(w, fmt.Sprintf("carnet-show-%d.pdf", show.ID), title, nil, headers, rows)
+	default:
+		RespondWithError(w, http.StatusBadRequest, "format must be csv or pdf")
+	}
+}
+
+func carnetDescription(g database.Gear) string {
+	if makeModel := strings.TrimSpace(g.Make + " " + g.Model); makeModel != "" {
+		return fmt.Sprintf("%s (%s)", g.Name, makeModel)
+	}
+	return g.Name
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func respondWithCSV(w http.ResponseWriter, filename string, headers []string, rows [][]string) {
+	var buf bytes.Buffer
+	out := csv.NewWriter(&buf)
+	out.Write(headers)
+	out.WriteAll(rows)
+	if err := out.Error(); err != nil {
+		log.Printf("failed to write csv: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write csv")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+func respondWithTablePDF(w http.ResponseWriter, filename, title string, notes []string, headers []string, rows [][]string) {
+	doc := pdf.New()
+	doc.Title(title)
+	for _, note := range notes {
+		doc.Paragraph(note)
+	}
+	doc.Table(headers, rows)
+
+	var buf bytes.Buffer
+	err := doc.Write(&buf)
+	if err != nil {
+		log.Printf("failed to render pdf: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to render pdf")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
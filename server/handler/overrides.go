@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/rider"
+)
+
+var errNoShowRider = errors.New("no rider revision is attached to this show")
+
+// effectiveShowRider layers a show's overrides over the rider revision
+// attached to it.
+func (cfg *config) effectiveShowRider(ctx context.Context, show database.Show) (database.RiderRevision, rider.Document, error) {
+	if !show.RiderRevisionID.Valid {
+		return database.RiderRevision{}, rider.Document{}, errNoShowRider
+	}
+
+	revision, err := cfg.db.GetRiderRevisionByID(ctx, show.RiderRevisionID.Int32)
+	if err != nil {
+		return revision, rider.Document{}, err
+	}
+
+	base, err := rider.Parse(revision.Document)
+	if err != nil {
+		return revision, rider.Document{}, err
+	}
+
+	doc, err := rider.ApplyPatch(base, show.RiderOverrides)
+	if err != nil {
+		return revision, doc, err
+	}
+
+	doc, err = cfg.expandDocument(ctx, show.BandID, revision, doc)
+	return revision, doc, err
+}
+
+// showRider is effectiveShowRider for handlers that respond with JSON errors.
+func (cfg *config) showRider(w http.ResponseWriter, r *http.Request, show database.Show) (database.RiderRevision, rider.Document, bool) {
+	revision, doc, err := cfg.effectiveShowRider(r.Context(), show)
+	if errors.Is(err, errNoShowRider) {
+		RespondWithError(w, http.StatusConflict, err.Error())
+		return revision, doc, false
+	} else if err != nil {
+		log.Printf("failed to build rider for show %d: %v", show.ID, err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to build the rider for this show")
+		return revision, doc, false
+	}
+	return revision, doc, true
+}
+
+func (cfg *config) GetShowOverrides(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, show.RiderOverrides)
+}
+
+// UpdateShowOverrides replaces the show's overrides with the merge patch in
+// the request body, as long as it still produces a valid rider. See
+// rider.ApplyPatch for changing single channels, mixes and stage items.
+func (cfg *config) UpdateShowOverrides(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+
+	var compact bytes.Buffer
+	err = json.Compact(&compact, patch)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "overrides must be a JSON merge patch")
+		return
+	}
+
+	// gear the rider or the old overrides already refer to is let through
+	// by checkGearRefs, even if it has since left the inventory
+	var replaced []rider.Document
+	revision, previous, err := cfg.effectiveShowRider(r.Context(), show)
+	if err == nil {
+		replaced = append(replaced, previous)
+	}
+	if base, err := rider.Parse(revision.Document); err == nil {
+		replaced = append(replaced, base)
+	}
+
+	show.RiderOverrides = compact.Bytes()
+	_, doc, err := cfg.effectiveShowRider(r.Context(), show)
+	if errors.Is(err, errNoShowRider) {
+		RespondWithError(w, http.StatusConflict, err.Error())
+		return
+	} else if errors.Is(err, rider.ErrPatchInvalid) {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if err != nil {
+		log.Printf("failed to build rider for show %d: %v", show.ID, err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to build the rider for this show")
+		return
+	}
+
+	err = doc.Validate()
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if !cfg.checkGearRefs(w, r, show.BandID, doc, replaced...) {
+		return
+	}
+
+	updated, err := cfg.db.UpdateShowOverrides(r.Context(), database.UpdateShowOverridesParams{
+		ID:             show.ID,
+		RiderOverrides: show.RiderOverrides,
+	})
+	if err != nil {
+		log.Printf("failed to update show overrides: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, updated.RiderOverrides)
+}
+
+func (cfg *config) GetShowRider(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	revision, doc, ok := cfg.showRider(w, r, show)
+	if !ok {
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]any{
+		"show_id":   show.ID,
+		"rider_id":  revision.RiderID,
+		"revision":  revision.Revision,
+		"overrides": show.RiderOverrides,
+		"document":  doc,
+	})
+}
+
+func (cfg *config) GetShowRiderPDF(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	revision, doc, ok := cfg.showRider(w, r, show)
+	if !ok {
+		return
+	}
+
+	cfg.respondWithPDF(w, r, show.BandID, showRiderTitle(show), revision.Revision, doc)
+}
+
+// GetShowVenueSupply summarizes what the venue has to provide or rent for a
+// show, based on the show's effective rider.
+func (cfg *config) GetShowVenueSupply(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	_, doc, ok := cfg.showRider(w, r, show)
+	if !ok {
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, doc.VenueSupply())
+}
+
+// CreateShowShareLink shares the show's effective rider rather than the
+// rider's latest published revision.
+func (cfg *config) CreateShowShareLink(w http.ResponseWriter, r *http.Request) {
+	show, membership, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	} else if !membership.AccountIsAdmin {
+		RespondWithError(w, http.StatusForbidden, "only band admins can share a rider")
+		return
+	}
+
+	var body struct {
+		ExpiresIn int `json:"expires_in"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	revision, _, ok := cfg.showRider(w, r, show)
+	if !ok {
+		return
+	}
+
+	link, err := cfg.createShareLink(r.Context(), revision.RiderID, membership.AccountID, body.ExpiresIn, sql.NullInt32{Int32: show.ID, Valid: true})
+	if err != nil {
+		log.Printf("failed to create share link: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to create a share link")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, shareLinkResponse(link))
+}
+
+func showRiderTitle(show database.Show) string {
+	return fmt.Sprintf("%s, %s", show.VenueName, show.Date.Format("2 Jan 2006"))
+}
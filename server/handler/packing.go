@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"cmp"
+	"encoding/json"
+	"log"
+	"net/http"
+	"slices"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/rider"
+)
+
+// looseCase groups packing list items that aren't in the inventory or don't
+// have a case yet.
+const looseCase = "Loose"
+
+type packingLine struct {
+	rider.PackingItem
+	Case    string                 `json:"case"`
+	LoadIn  *database.PackingCheck `json:"load_in"`
+	LoadOut *database.PackingCheck `json:"load_out"`
+	// gear is the inventory item behind the line, if there is one.
+	gear *database.Gear
+}
+
+// packingList builds a show's packing list from its effective rider, with
+// each item's case from the gear inventory and its check offs so far.
+func (cfg *config) packingList(w http.ResponseWriter, r *http.Request, show database.Show) ([]packingLine, bool) {
+	_, doc, ok := cfg.showRider(w, r, show)
+	if !ok {
+		return nil, false
+	}
+
+	gear, err := cfg.db.GetBandGear(r.Context(), show.BandID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return nil, false
+	}
+	inventory := make(map[int32]*database.Gear, len(gear))
+	for i := range gear {
+		inventory[gear[i].ID] = &gear[i]
+	}
+
+	checks, err := cfg.db.GetShowPackingChecks(r.Context(), show.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return nil, false
+	}
+
+	items := doc.Packing()
+	lines := make([]packingLine, 0, len(items))
+	for _, item := range items {
+		line := packingLine{PackingItem: item, Case: looseCase, gear: inventory[item.GearID]}
+		if line.gear != nil {
+			line.Case = cmp.Or(line.gear.CaseName, looseCase)
+		}
+		for _, check := range checks {
+			if check.ItemKey != item.Key {
+				continue
+			}
+			switch check.Stage {
+			case database.PackingStageLoadIn:
+				line.LoadIn = &check
+			case database.PackingStageLoadOut:
+				line.LoadOut = &check
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	slices.SortStableFunc(lines, func(a, b packingLine) int {
+		if (a.Case == looseCase) != (b.Case == looseCase) {
+			if a.Case == looseCase {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(a.Case, b.Case)
+	})
+	return lines, true
+}
+
+// GetShowPackingList lists what the band has to bring to a show, grouped by
+// case.
+func (cfg *config) GetShowPackingList(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	lines, ok := cfg.packingList(w, r, show)
+	if !ok {
+		return
+	}
+
+	type packingCase struct {
+		Case  string        `json:"case"`
+		Items []packingLine `json:"items"`
+	}
+	cases := []packingCase{}
+	for _, line := range lines {
+		if len(cases) == 0 || cases[len(cases)-1].Case != line.Case {
+			cases = append(cases, packingCase{Case: line.Case})
+		}
+		cases[len(cases)-1].Items = append(cases[len(cases)-1].Items, line)
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]any{
+		"show_id": show.ID,
+		"cases":   cases,
+	})
+}
+
+// CheckPackingItem ticks an item off, or un-ticks it, at load in or load out.
+func (cfg *config) CheckPackingItem(w http.ResponseWriter, r *http.Request) {
+	show, membership, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Item    string                `json:"item"`
+		Stage   database.PackingStage `json:"stage"`
+		Checked bool                  `json:"checked"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	} else if body.Stage != database.PackingStageLoadIn && body.Stage != database.PackingStageLoadOut {
+		RespondWithError(w, http.StatusBadRequest, "stage must be load_in or load_out")
+		return
+	}
+
+	lines, ok := cfg.packingList(w, r, show)
+	if !ok {
+		return
+	}
+	known := slices.ContainsFunc(lines, func(line packingLine) bool {
+		return line.Key == body.Item
+	})
+	if !known {
+		RespondWithError(w, http.StatusBadRequest, "that item is not on this show's packing list")
+		return
+	}
+
+	if !body.Checked {
+		_, err = cfg.db.DeletePackingCheck(r.Context(), database.DeletePackingCheckParams{
+			ShowID:  show.ID,
+			ItemKey: body.Item,
+			Stage:   body.Stage,
+		})
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	check, err := cfg.db.UpsertPackingCheck(r.Context(), database.UpsertPackingCheckParams{
+		ShowID:    show.ID,
+		ItemKey:   body.Item,
+		Stage:     body.Stage,
+		CheckedBy: membership.AccountID,
+	})
+	if err != nil {
+		log.Printf("failed to check packing item: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, check)
+}
+
+// GetShowPackingMissing reports what came in at load in but hasn't been
+// checked back out, along with anything that was never checked in at all.
+func (cfg *config) GetShowPackingMissing(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	lines, ok := cfg.packingList(w, r, show)
+	if !ok {
+		return
+	}
+
+	missing := []packingLine{}
+	unchecked := []packingLine{}
+	for _, line := range lines {
+		switch {
+		case line.LoadIn == nil:
+			unchecked = append(unchecked, line)
+		case line.LoadOut == nil:
+			missing = append(missing, line)
+		}
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]any{
+		"show_id":   show.ID,
+		"missing":   missing,
+		"unchecked": unchecked,
+	})
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/rider"
+)
+
+// GetRiderPatch works out the stage box patch for the latest revision of a
+// rider and exports it as a patch sheet.
+func (cfg *config) GetRiderPatch(w http.ResponseWriter, r *http.Request) {
+	rd, _, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	}
+
+	revision, doc, ok := cfg.latestRider(w, r, rd)
+	if !ok {
+		return
+	}
+
+	respondWithPatch(w, r, revision, doc)
+}
+
+// GetShowPatch works out the stage box patch for a show's effective rider,
+// which is where the venue's own stage boxes are usually described.
+func (cfg *config) GetShowPatch(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	revision, doc, ok := cfg.showRider(w, r, show)
+	if !ok {
+		return
+	}
+
+	respondWithPatch(w, r, revision, doc)
+}
+
+func respondWithPatch(w http.ResponseWriter, r *http.Request, revision database.RiderRevision, doc rider.Document) {
+	if doc.Patch == nil || len(doc.Patch.StageBoxes) == 0 {
+		RespondWithError(w, http.StatusConflict, "this rider doesn't describe any stage boxes")
+		return
+	}
+
+	lines, warnings := doc.PatchSheet()
+	table := rider.Table{
+		Title:   "Patch",
+		Headers: []string{"Ch", "Source", "Stage box", "Input", "Set by"},
+	}
+	for _, line := range lines {
+		input, setBy := "", "auto"
+		if line.Input > 0 {
+			input = strconv.Itoa(line.Input)
+		}
+		if line.Manual {
+			setBy = "hand"
+		}
+		table.Rows = append(table.Rows, []string{strconv.Itoa(line.Channel), line.Source, line.Box, input, setBy})
+	}
+
+	respondWithTables(w, r, fmt.Sprintf("patch-r%d", revision.Revision), []rider.Table{table}, map[string]any{
+		"revision": revision.Revision,
+		"patch":    lines,
+		"warnings": warnings,
+	})
+}
@@ -0,0 +1,35 @@
+package handler
+
+import "net/http"
+
+// GetRiderPower adds up the stage plot's power loads for the latest revision
+// of a rider, so they can be checked while it's being written.
+func (cfg *config) GetRiderPower(w http.ResponseWriter, r *http.Request) {
+	rd, _, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	}
+
+	_, doc, ok := cfg.latestRider(w, r, rd)
+	if !ok {
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, doc.Power())
+}
+
+// GetShowPower adds up the power loads for a show's effective rider, taking
+// in any drops or circuits changed for the venue.
+func (cfg *config) GetShowPower(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	_, doc, ok := cfg.showRider(w, r, show)
+	if !ok {
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, doc.Power())
+}
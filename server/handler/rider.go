@@ -0,0 +1,418 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/rider"
+)
+
+func (cfg *config) CreateRider(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		BandID   int32          `json:"band_id"`
+		Name     string         `json:"name"`
+		Document rider.Document `json:"document"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	} else if body.Name == "" {
+		RespondWithError(w, http.StatusBadRequest, "rider name is required")
+		return
+	}
+
+	membership, ok := cfg.membership(w, r, body.BandID)
+	if !ok {
+		return
+	} else if !cfg.checkGearRefs(w, r, membership.BandID, body.Document) {
+		return
+	}
+
+	document, ok := encodeDocument(w, body.Document)
+	if !ok {
+		return
+	}
+
+	tx, err := cfg.conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	rd, err := qtx.CreateRider(r.Context(), database.CreateRiderParams{
+		BandID: membership.BandID,
+		Name:   body.Name,
+	})
+	if err != nil {
+		log.Printf("failed to create rider: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	revision, err := qtx.CreateRiderRevision(r.Context(), database.CreateRiderRevisionParams{
+		RiderID:  rd.ID,
+		Document: document,
+		AuthorID: membership.AccountID,
+	})
+	if err != nil {
+		log.Printf("failed to create rider revision: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, map[string]any{
+		"id":         rd.ID,
+		"band_id":    rd.BandID,
+		"name":       rd.Name,
+		"created_at": rd.CreatedAt,
+		"updated_at": rd.UpdatedAt,
+		"revision":   revision,
+	})
+}
+
+func (cfg *config) GetBandRiders(w http.ResponseWriter, r *http.Request) {
+	membership, ok := cfg.bandAccess(w, r)
+	if !ok {
+		return
+	}
+
+	riders, err := cfg.db.GetBandRiders(r.Context(), membership.BandID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, riders)
+}
+
+func (cfg *config) GetRider(w http.ResponseWriter, r *http.Request) {
+	rd, _, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	}
+
+	revision, err := cfg.db.GetLatestRiderRevision(r.Context(), rd.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]any{
+		"id":         rd.ID,
+		"band_id":    rd.BandID,
+		"name":       rd.Name,
+		"created_at": rd.CreatedAt,
+		"updated_at": rd.UpdatedAt,
+		"revision":   revision,
+	})
+}
+
+func (cfg *config) GetRiderRevisions(w http.ResponseWriter, r *http.Request) {
+	rd, _, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	}
+
+	revisions, err := cfg.db.GetRiderRevisions(r.Context(), rd.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, revisions)
+}
+
+func (cfg *config) GetPublishedRider(w http.ResponseWriter, r *http.Request) {
+	rd, _, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	}
+
+	revision, err := cfg.db.GetPublishedRiderRevision(r.Context(), rd.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusNotFound, "this rider has not been published")
+		return
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, revision)
+}
+
+// UpdateRider saves a new document for the rider. Drafts and revisions in
+// review are edited in place, while a published rider is frozen and editing
+// it forks a new draft revision instead.
+func (cfg *config) UpdateRider(w http.ResponseWriter, r *http.Request) {
+	rd, membership, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Document rider.Document `json:"document"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	document, ok := encodeDocument(w, body.Document)
+	if !ok {
+		return
+	}
+
+	latest, err := cfg.db.GetLatestRiderRevision(r.Context(), rd.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	previous, err := rider.Parse(latest.Document)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to decode rider document")
+		return
+	} else if !cfg.checkGearRefs(w, r, rd.BandID, body.Document, previous) {
+		return
+	}
+
+	revision, forked, err := saveRiderDocument(r.Context(), cfg.db, latest, membership.AccountID, document)
+	if err != nil {
+		log.Printf("failed to save rider revision: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	} else if forked {
+		RespondWithJSON(w, http.StatusCreated, revision)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, revision)
+}
+
+// saveRiderDocument writes a new document over the latest revision if it's
+// still a draft or in review, or forks a new draft from it otherwise.
+func saveRiderDocument(ctx context.Context, q *database.Queries, latest database.RiderRevision, authorID int32, document json.RawMessage) (database.RiderRevision, bool, error) {
+	if editable(latest) {
+		revision, err := q.UpdateRiderRevisionDocument(ctx, database.UpdateRiderRevisionDocumentParams{
+			ID:       latest.ID,
+			Document: document,
+		})
+		if !errors.Is(err, sql.ErrNoRows) {
+			return revision, false, err
+		}
+		// the revision was published out from under us, so fall through and fork it
+	}
+
+	revision, err := q.CreateRiderRevision(ctx, database.CreateRiderRevisionParams{
+		RiderID:  latest.RiderID,
+		Document: document,
+		AuthorID: authorID,
+	})
+	return revision, true, err
+}
+
+func (cfg *config) SubmitRider(w http.ResponseWriter, r *http.Request) {
+	rd, _, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	}
+
+	latest, err := cfg.db.GetLatestRiderRevision(r.Context(), rd.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	revision, err := cfg.db.SubmitRiderRevision(r.Context(), latest.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusConflict, "only a draft can be submitted for review")
+		return
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, revision)
+}
+
+func (cfg *config) PublishRider(w http.ResponseWriter, r *http.Request) {
+	rd, membership, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	} else if !membership.AccountIsAdmin {
+		RespondWithError(w, http.StatusForbidden, "only band admins can publish a rider")
+		return
+	}
+
+	tx, err := cfg.conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	latest, err := qtx.GetLatestRiderRevision(r.Context(), rd.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	} else if !editable(latest) {
+		RespondWithError(w, http.StatusConflict, "there are no unpublished changes to this rider")
+		return
+	}
+
+	// members' dietary restrictions are frozen along with the rest of the
+	// revision, so what venues see only changes with a new revision
+	doc, err := rider.Parse(latest.Document)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to decode rider document")
+		return
+	}
+	if doc.Hospitality != nil && doc.Hospitality.UseMemberDietary {
+		doc.Hospitality.MemberDietary, err = cfg.memberDietary(r.Context(), rd.BandID)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+			return
+		}
+		document, err := json.Marshal(doc)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "failed to encode rider document")
+			return
+		}
+		latest, err = qtx.UpdateRiderRevisionDocument(r.Context(), database.UpdateRiderRevisionDocumentParams{
+			ID:       latest.ID,
+			Document: document,
+		})
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+			return
+		}
+	}
+
+	err = qtx.SupersedeRiderRevisions(r.Context(), rd.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	revision, err := qtx.PublishRiderRevision(r.Context(), database.PublishRiderRevisionParams{
+		ID:          latest.ID,
+		PublishedBy: sql.NullInt32{Int32: membership.AccountID, Valid: true},
+	})
+	if err != nil {
+		log.Printf("failed to publish rider revision: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, revision)
+}
+
+// latestRider reads out the latest revision of a rider, whatever its
+// status.
+func (cfg *config) latestRider(w http.ResponseWriter, r *http.Request, rd database.Rider) (database.RiderRevision, rider.Document, bool) {
+	revision, err := cfg.db.GetLatestRiderRevision(r.Context(), rd.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return revision, rider.Document{}, false
+	}
+
+	doc, err := rider.Parse(revision.Document)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to decode rider document")
+		return revision, doc, false
+	}
+
+	doc, err = cfg.expandDocument(r.Context(), rd.BandID, revision, doc)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return revision, doc, false
+	}
+	return revision, doc, true
+}
+
+// expandDocument fills in the parts of a rider that live elsewhere in the
+// app, for anywhere a rider is read out.
+func (cfg *config) expandDocument(ctx context.Context, bandID int32, revision database.RiderRevision, doc rider.Document) (rider.Document, error) {
+	doc, err := cfg.fillMemberDietary(ctx, bandID, revision, doc)
+	if err != nil {
+		return doc, err
+	}
+	doc, err = cfg.fillGear(ctx, bandID, doc)
+	return doc.WithCatalog(), err
+}
+
+// fillMemberDietary adds band members' dietary restrictions to a rider that
+// asks for them. Drafts follow members' profiles as they change, while a
+// published revision keeps the restrictions it was published with.
+func (cfg *config) fillMemberDietary(ctx context.Context, bandID int32, revision database.RiderRevision, doc rider.Document) (rider.Document, error) {
+	if doc.Hospitality == nil || !doc.Hospitality.UseMemberDietary {
+		return doc, nil
+	} else if !editable(revision) {
+		return doc.WithMemberDietary(doc.Hospitality.MemberDietary), nil
+	}
+
+	needs, err := cfg.memberDietary(ctx, bandID)
+	if err != nil {
+		return doc, err
+	}
+	return doc.WithMemberDietary(needs), nil
+}
+
+func (cfg *config) memberDietary(ctx context.Context, bandID int32) ([]rider.DietaryNeed, error) {
+	members, err := cfg.db.GetBandMemberDietaryRestrictions(ctx, bandID)
+	if err != nil {
+		return nil, err
+	}
+
+	needs := make([]rider.DietaryNeed, 0, len(members))
+	for _, member := range members {
+		needs = append(needs, rider.DietaryNeed{
+			Member:       strings.TrimSpace(member.GivenName + " " + member.FamilyName),
+			Restrictions: member.DietaryRestrictions,
+		})
+	}
+	return needs, nil
+}
+
+func editable(revision database.RiderRevision) bool {
+	return revision.Status == database.RiderStatusDraft || revision.Status == database.RiderStatusInReview
+}
+
+func encodeDocument(w http.ResponseWriter, doc rider.Document) (json.RawMessage, bool) {
+	doc = doc.WithLineIDs()
+	err := doc.Validate()
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	document, err := json.Marshal(doc)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to encode rider document")
+		return nil, false
+	}
+	return document, true
+}
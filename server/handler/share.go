@@ -0,0 +1,381 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"embed"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/rider"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+var shareTemplate = template.Must(template.ParseFS(templateFS, "templates/share.html"))
+
+func (cfg *config) CreateShareLink(w http.ResponseWriter, r *http.Request) {
+	rd, membership, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	} else if !membership.AccountIsAdmin {
+		RespondWithError(w, http.StatusForbidden, "only band admins can share a rider")
+		return
+	}
+
+	var body struct {
+		ExpiresIn int `json:"expires_in"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	_, err = cfg.db.GetPublishedRiderRevision(r.Context(), rd.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusConflict, "a rider must be published before it can be shared")
+		return
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	link, err := cfg.createShareLink(r.Context(), rd.ID, membership.AccountID, body.ExpiresIn, sql.NullInt32{})
+	if err != nil {
+		log.Printf("failed to create share link: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to create a share link")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, shareLinkResponse(link))
+}
+
+func (cfg *config) createShareLink(ctx context.Context, riderID, creatorID int32, expiresIn int, showID sql.NullInt32) (database.ShareLink, error) {
+	var expiresAt sql.NullTime
+	if expiresIn > 0 {
+		expiresAt = sql.NullTime{
+			Time:  time.Now().Add(time.Second * time.Duration(expiresIn)),
+			Valid: true,
+		}
+	}
+
+	token, err := generateSecretToken()
+	if err != nil {
+		return database.ShareLink{}, err
+	}
+
+	return cfg.db.CreateShareLink(ctx, database.CreateShareLinkParams{
+		RiderID:   riderID,
+		Token:     token,
+		CreatorID: creatorID,
+		ExpiresAt: expiresAt,
+		ShowID:    showID,
+	})
+}
+
+func (cfg *config) GetShareLinks(w http.ResponseWriter, r *http.Request) {
+	rd, _, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	}
+
+	links, err := cfg.db.GetRiderShareLinks(r.Context(), rd.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	res := make([]map[string]any, 0, len(links))
+	for _, link := range links {
+		res = append(res, shareLinkResponse(link))
+	}
+	RespondWithJSON(w, http.StatusOK, res)
+}
+
+func (cfg *config) RevokeShareLink(w http.ResponseWriter, r *http.Request) {
+	rd, membership, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	} else if !membership.AccountIsAdmin {
+		RespondWithError(w, http.StatusForbidden, "only band admins can revoke a share link")
+		return
+	}
+
+	linkID, err := strconv.Atoi(r.PathValue("link_id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "invalid share link id")
+		return
+	}
+
+	link, err := cfg.db.RevokeShareLink(r.Context(), database.RevokeShareLinkParams{
+		ID:      int32(linkID),
+		RiderID: rd.ID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusNotFound, "no matching share link, or it was already revoked")
+		return
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, shareLinkResponse(link))
+}
+
+func (cfg *config) GetRiderPDF(w http.ResponseWriter, r *http.Request) {
+	rd, _, ok := cfg.riderAccess(w, r)
+	if !ok {
+		return
+	}
+
+	revision, err := cfg.db.GetLatestRiderRevision(r.Context(), rd.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	doc, err := rider.Parse(revision.Document)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to decode rider document")
+		return
+	}
+
+	doc, err = cfg.expandDocument(r.Context(), rd.BandID, revision, doc)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	cfg.respondWithPDF(w, r, rd.BandID, rd.Name, revision.Revision, doc)
+}
+
+// sharedRider is everything a venue gets to see through a share link.
+type sharedRider struct {
+	link     database.ShareLink
+	band     database.Band
+	rider    database.Rider
+	show     *database.Show
+	revision database.RiderRevision
+	document rider.Document
+}
+
+func (shared sharedRider) title() string {
+	title := fmt.Sprintf("%s - %s", shared.band.Name, shared.rider.Name)
+	if shared.show != nil {
+		title = fmt.Sprintf("%s - %s", title, showRiderTitle(*shared.show))
+	}
+	return title
+}
+
+// resolveShareLink looks up the share link named by the token path value and
+// the rider it points at: the rider's published revision, or for a link made
+// from a show, that show's effective rider. When it returns false a plain
+// text error has already been written, since these pages are read in a
+// browser rather than by the client app.
+func (cfg *config) resolveShareLink(w http.ResponseWriter, r *http.Request) (sharedRider, bool) {
+	var shared sharedRider
+	link, err := cfg.db.GetShareLinkByToken(r.Context(), r.PathValue("token"))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && link.RevokedAt.Valid) {
+		http.Error(w, "this link is not valid", http.StatusNotFound)
+		return shared, false
+	} else if err != nil {
+		http.Error(w, "unexpected database error", http.StatusInternalServerError)
+		return shared, false
+	} else if link.ExpiresAt.Valid && link.ExpiresAt.Time.Before(time.Now()) {
+		http.Error(w, "this link has expired, please ask the band for a new one", http.StatusGone)
+		return shared, false
+	}
+	shared.link = link
+
+	shared.rider, err = cfg.db.GetRider(r.Context(), link.RiderID)
+	if err != nil {
+		http.Error(w, "unexpected database error", http.StatusInternalServerError)
+		return shared, false
+	}
+
+	shared.band, err = cfg.db.GetBandByID(r.Context(), shared.rider.BandID)
+	if err != nil {
+		http.Error(w, "unexpected database error", http.StatusInternalServerError)
+		return shared, false
+	}
+
+	if link.ShowID.Valid {
+		show, err := cfg.db.GetShow(r.Context(), link.ShowID.Int32)
+		if err != nil {
+			http.Error(w, "unexpected database error", http.StatusInternalServerError)
+			return shared, false
+		}
+		shared.show = &show
+
+		shared.revision, shared.document, err = cfg.effectiveShowRider(r.Context(), show)
+		if errors.Is(err, errNoShowRider) {
+			http.Error(w, "this show no longer has a rider attached", http.StatusNotFound)
+			return shared, false
+		} else if err != nil {
+			log.Printf("failed to build rider for show %d: %v", show.ID, err)
+			http.Error(w, "failed to build the rider for this show", http.StatusInternalServerError)
+			return shared, false
+		}
+		return shared, true
+	}
+
+	shared.revision, err = cfg.db.GetPublishedRiderRevision(r.Context(), link.RiderID)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "this rider is not currently published", http.StatusNotFound)
+		return shared, false
+	} else if err != nil {
+		http.Error(w, "unexpected database error", http.StatusInternalServerError)
+		return shared, false
+	}
+
+	shared.document, err = rider.Parse(shared.revision.Document)
+	if err != nil {
+		http.Error(w, "failed to decode rider document", http.StatusInternalServerError)
+		return shared, false
+	}
+
+	shared.document, err = cfg.expandDocument(r.Context(), shared.rider.BandID, shared.revision, shared.document)
+	if err != nil {
+		http.Error(w, "unexpected database error", http.StatusInternalServerError)
+		return shared, false
+	}
+
+	return shared, true
+}
+
+func (cfg *config) recordShareLinkView(r *http.Request, link database.ShareLink) {
+	err := cfg.db.RecordShareLinkView(r.Context(), link.ID)
+	if err != nil {
+		// NOTE: a missed view count isn't worth turning the venue away over
+		log.Printf("failed to record view of share link %d: %v", link.ID, err)
+	}
+}
+
+func (cfg *config) ViewSharedRider(w http.ResponseWriter, r *http.Request) {
+	shared, ok := cfg.resolveShareLink(w, r)
+	if !ok {
+		return
+	}
+	cfg.recordShareLinkView(r, shared.link)
+
+	responses, err := cfg.db.GetShareLinkResponses(r.Context(), database.GetShareLinkResponsesParams{
+		ShareLinkID: shared.link.ID,
+		RevisionID:  shared.revision.ID,
+	})
+	if err != nil {
+		http.Error(w, "unexpected database error", http.StatusInternalServerError)
+		return
+	}
+
+	symbols, err := cfg.bandSymbols(r.Context(), shared.band.ID)
+	if err != nil {
+		log.Printf("failed to load stage symbols: %v", err)
+		http.Error(w, "failed to render rider", http.StatusInternalServerError)
+		return
+	}
+
+	var publishedAt *time.Time
+	if shared.revision.PublishedAt.Valid {
+		publishedAt = &shared.revision.PublishedAt.Time
+	}
+
+	var buf bytes.Buffer
+	err = shareTemplate.Execute(&buf, map[string]any{
+		"Title":       shared.title(),
+		"Revision":    shared.revision.Revision,
+		"PublishedAt": publishedAt,
+		"BasePath":    fmt.Sprintf("/share/%s", shared.link.Token),
+		"Sections":    rider.WithSymbols(shared.document.Sections(), symbols),
+		"LineItems":   shared.document.LineItems(),
+		"Responses":   responses,
+		"Thanks":      r.URL.Query().Has("thanks"),
+	})
+	if err != nil {
+		log.Printf("failed to render share page: %v", err)
+		http.Error(w, "failed to render rider", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+func (cfg *config) GetSharedRiderPDF(w http.ResponseWriter, r *http.Request) {
+	shared, ok := cfg.resolveShareLink(w, r)
+	if !ok {
+		return
+	}
+	cfg.recordShareLinkView(r, shared.link)
+
+	cfg.respondWithPDF(w, r, shared.band.ID, shared.title(), shared.revision.Revision, shared.document)
+}
+
+func (cfg *config) respondWithPDF(w http.ResponseWriter, r *http.Request, bandID int32, title string, revision int32, doc rider.Document) {
+	symbols, err := cfg.bandSymbols(r.Context(), bandID)
+	if err != nil {
+		log.Printf("failed to load stage symbols: %v", err)
+		http.Error(w, "failed to render pdf", http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = rider.WritePDF(&buf, title, revision, doc, symbols)
+	if err != nil {
+		log.Printf("failed to render pdf: %v", err)
+		http.Error(w, "failed to render pdf", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", fmt.Sprintf("rider-r%d.pdf", revision)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+func generateSecretToken() (string, error) {
+	buf := make([]byte, 24)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func shareLinkResponse(link database.ShareLink) map[string]any {
+	var showID *int32
+	if link.ShowID.Valid {
+		showID = &link.ShowID.Int32
+	}
+
+	return map[string]any{
+		"id":               link.ID,
+		"rider_id":         link.RiderID,
+		"show_id":          showID,
+		"url":              fmt.Sprintf("/share/%s", link.Token),
+		"created_at":       link.CreatedAt,
+		"expires_at":       nullTime(link.ExpiresAt),
+		"revoked_at":       nullTime(link.RevokedAt),
+		"view_count":       link.ViewCount,
+		"last_accessed_at": nullTime(link.LastAccessedAt),
+	}
+}
+
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
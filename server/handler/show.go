@@ -0,0 +1,377 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/rider"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// wallClockLayout is how schedule times are sent and received. They
+	// carry no offset because they are read in the show's own timezone.
+	wallClockLayout = "2006-01-02T15:04"
+)
+
+type showBody struct {
+	BandID          int32  `json:"band_id"`
+	Date            string `json:"date"`
+	VenueName       string `json:"venue_name"`
+	City            string `json:"city"`
+	Timezone        string `json:"timezone"`
+	LoadIn          string `json:"load_in"`
+	Soundcheck      string `json:"soundcheck"`
+	Doors           string `json:"doors"`
+	Set             string `json:"set"`
+	Status          string `json:"status"`
+	RiderRevisionID int32  `json:"rider_revision_id"`
+	// VenueID links the show to one of the band's venues, which fills in
+	// the venue name, city and timezone when they're left out.
+	VenueID int32 `json:"venue_id"`
+	TourID  int32 `json:"tour_id"`
+	// Latitude and Longitude place the show on its tour's routing, and
+	// default to the venue's.
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+func (b showBody) parse() (database.CreateShowParams, error) {
+	params := database.CreateShowParams{
+		BandID:    b.BandID,
+		VenueName: strings.TrimSpace(b.VenueName),
+		City:      strings.TrimSpace(b.City),
+		Timezone:  b.Timezone,
+		Status:    database.ShowStatus(b.Status),
+	}
+	if params.VenueName == "" {
+		return params, errors.New("venue name is required")
+	}
+
+	date, err := time.Parse(dateLayout, b.Date)
+	if err != nil {
+		return params, fmt.Errorf("date must look like %s", dateLayout)
+	}
+	params.Date = date
+
+	if params.Timezone == "" {
+		params.Timezone = "UTC"
+	}
+	_, err = time.LoadLocation(params.Timezone)
+	if err != nil {
+		return params, fmt.Errorf("unknown timezone %q", params.Timezone)
+	}
+
+	switch params.Status {
+	case "":
+		params.Status = database.ShowStatusHold
+	case database.ShowStatusHold, database.ShowStatusConfirmed, database.ShowStatusCancelled:
+	default:
+		return params, fmt.Errorf("unknown show status %q", b.Status)
+	}
+
+	times := []struct {
+		name  string
+		value string
+		dest  *sql.NullTime
+	}{
+		{"load_in", b.LoadIn, &params.LoadInAt},
+		{"soundcheck", b.Soundcheck, &params.SoundcheckAt},
+		{"doors", b.Doors, &params.DoorsAt},
+		{"set", b.Set, &params.SetAt},
+	}
+	for _, t := range times {
+		if t.value == "" {
+			continue
+		}
+		parsed, err := time.Parse(wallClockLayout, t.value)
+		if err != nil {
+			return params, fmt.Errorf("%s must look like %s", t.name, wallClockLayout)
+		}
+		*t.dest = sql.NullTime{Time: parsed, Valid: true}
+	}
+
+	if b.RiderRevisionID != 0 {
+		params.RiderRevisionID = sql.NullInt32{Int32: b.RiderRevisionID, Valid: true}
+	}
+	if b.VenueID != 0 {
+		params.VenueID = sql.NullInt32{Int32: b.VenueID, Valid: true}
+	}
+	if b.TourID != 0 {
+		params.TourID = sql.NullInt32{Int32: b.TourID, Valid: true}
+	}
+	params.Latitude, params.Longitude, err = parseCoordinates(b.Latitude, b.Longitude)
+	return params, err
+}
+
+// checkShowRevision makes sure a rider revision attached to a show belongs to
+// the band playing it and has been published, since drafts can still change
+// underneath the venue.
+func (cfg *config) checkShowRevision(w http.ResponseWriter, r *http.Request, bandID int32, revisionID sql.NullInt32) bool {
+	if !revisionID.Valid {
+		return true
+	}
+
+	revision, err := cfg.db.GetRiderRevisionByID(r.Context(), revisionID.Int32)
+	if errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusBadRequest, "no matching rider revision")
+		return false
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return false
+	}
+
+	rd, err := cfg.db.GetRider(r.Context(), revision.RiderID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return false
+	} else if rd.BandID != bandID {
+		RespondWithError(w, http.StatusBadRequest, "no matching rider revision")
+		return false
+	} else if !revision.PublishedAt.Valid {
+		RespondWithError(w, http.StatusBadRequest, "only a published rider revision can be attached to a show")
+		return false
+	}
+	return true
+}
+
+func (cfg *config) CreateShow(w http.ResponseWriter, r *http.Request) {
+	var body showBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	_, ok := cfg.membership(w, r, body.BandID)
+	if !ok {
+		return
+	} else if !cfg.showVenue(w, r, body.BandID, &body) {
+		return
+	}
+
+	params, err := body.parse()
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !cfg.checkShowRevision(w, r, params.BandID, params.RiderRevisionID) {
+		return
+	} else if !cfg.checkShowTour(w, r, params.BandID, params.TourID) {
+		return
+	}
+
+	show, err := cfg.db.CreateShow(r.Context(), params)
+	if err != nil {
+		log.Printf("failed to create show: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, showResponse(show))
+}
+
+// GetBandShows lists a band's shows, optionally narrowed to upcoming or past
+// shows with ?when= and to a date range with ?from= and ?to=.
+func (cfg *config) GetBandShows(w http.ResponseWriter, r *http.Request) {
+	membership, ok := cfg.bandAccess(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	params := database.GetBandShowsParams{BandID: membership.BandID}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	switch query.Get("when") {
+	case "":
+	case "upcoming":
+		params.StartsOn = sql.NullTime{Time: today, Valid: true}
+	case "past":
+		params.EndsOn = sql.NullTime{Time: today.AddDate(0, 0, -1), Valid: true}
+	default:
+		RespondWithError(w, http.StatusBadRequest, "when must be upcoming or past")
+		return
+	}
+
+	if from := query.Get("from"); from != "" {
+		date, err := time.Parse(dateLayout, from)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("from must look like %s", dateLayout))
+			return
+		}
+		if !params.StartsOn.Valid || date.After(params.StartsOn.Time) {
+			params.StartsOn = sql.NullTime{Time: date, Valid: true}
+		}
+	}
+	if to := query.Get("to"); to != "" {
+		date, err := time.Parse(dateLayout, to)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("to must look like %s", dateLayout))
+			return
+		}
+		if !params.EndsOn.Valid || date.Before(params.EndsOn.Time) {
+			params.EndsOn = sql.NullTime{Time: date, Valid: true}
+		}
+	}
+
+	shows, err := cfg.db.GetBandShows(r.Context(), params)
+	if err != nil {
+		log.Printf("failed to fetch shows: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+	if query.Get("when") == "past" {
+		// most recent first reads better when looking back
+		slices.Reverse(shows)
+	}
+
+	res := make([]map[string]any, 0, len(shows))
+	for _, show := range shows {
+		res = append(res, showResponse(show))
+	}
+	RespondWithJSON(w, http.StatusOK, res)
+}
+
+func (cfg *config) GetShow(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, showResponse(show))
+}
+
+func (cfg *config) UpdateShow(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	var body showBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+	// shows can't be moved between bands
+	body.BandID = show.BandID
+	if !cfg.showVenue(w, r, show.BandID, &body) {
+		return
+	}
+
+	params, err := body.parse()
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !cfg.checkShowRevision(w, r, show.BandID, params.RiderRevisionID) {
+		return
+	} else if !cfg.checkShowTour(w, r, show.BandID, params.TourID) {
+		return
+	}
+
+	// the overrides were checked against the old revision, so they have to
+	// still make a valid rider on top of the new one
+	if params.RiderRevisionID.Valid && params.RiderRevisionID != show.RiderRevisionID {
+		moved := show
+		moved.RiderRevisionID = params.RiderRevisionID
+		_, doc, err := cfg.effectiveShowRider(r.Context(), moved)
+		if errors.Is(err, rider.ErrPatchInvalid) {
+			RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("the show's overrides don't fit this rider revision: %v", err))
+			return
+		} else if err != nil {
+			log.Printf("failed to build rider for show %d: %v", show.ID, err)
+			RespondWithError(w, http.StatusInternalServerError, "failed to build the rider for this show")
+			return
+		}
+		err = doc.Validate()
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("the show's overrides don't fit this rider revision: %v", err))
+			return
+		}
+	}
+
+	updated, err := cfg.db.UpdateShow(r.Context(), database.UpdateShowParams{
+		ID:              show.ID,
+		Date:            params.Date,
+		VenueName:       params.VenueName,
+		City:            params.City,
+		Timezone:        params.Timezone,
+		LoadInAt:        params.LoadInAt,
+		SoundcheckAt:    params.SoundcheckAt,
+		DoorsAt:         params.DoorsAt,
+		SetAt:           params.SetAt,
+		Status:          params.Status,
+		RiderRevisionID: params.RiderRevisionID,
+		VenueID:         params.VenueID,
+		TourID:          params.TourID,
+		Latitude:        params.Latitude,
+		Longitude:       params.Longitude,
+	})
+	if err != nil {
+		log.Printf("failed to update show: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, showResponse(updated))
+}
+
+func showResponse(show database.Show) map[string]any {
+	wallClock := func(t sql.NullTime) *string {
+		if !t.Valid {
+			return nil
+		}
+		s := t.Time.Format(wallClockLayout)
+		return &s
+	}
+
+	var revisionID *int32
+	if show.RiderRevisionID.Valid {
+		revisionID = &show.RiderRevisionID.Int32
+	}
+
+	var venueID *int32
+	if show.VenueID.Valid {
+		venueID = &show.VenueID.Int32
+	}
+
+	var tourID *int32
+	if show.TourID.Valid {
+		tourID = &show.TourID.Int32
+	}
+
+	return map[string]any{
+		"id":                show.ID,
+		"band_id":           show.BandID,
+		"date":              show.Date.Format(dateLayout),
+		"venue_name":        show.VenueName,
+		"city":              show.City,
+		"timezone":          show.Timezone,
+		"load_in":           wallClock(show.LoadInAt),
+		"soundcheck":        wallClock(show.SoundcheckAt),
+		"doors":             wallClock(show.DoorsAt),
+		"set":               wallClock(show.SetAt),
+		"status":            show.Status,
+		"rider_revision_id": revisionID,
+		"venue_id":          venueID,
+		"tour_id":           tourID,
+		"latitude":          nullFloat(show.Latitude),
+		"longitude":         nullFloat(show.Longitude),
+		"parking_notes":     show.ParkingNotes,
+		"wifi_notes":        show.WifiNotes,
+		"day_notes":         show.DayNotes,
+		"created_at":        show.CreatedAt,
+		"updated_at":        show.UpdatedAt,
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/symbol"
+)
+
+const maxSymbolBytes = 256 << 10
+
+// GetStageSymbols lists the symbols a band's stage plots are drawn with: the
+// built in library, and the band's own uploads which take precedence over it.
+func (cfg *config) GetStageSymbols(w http.ResponseWriter, r *http.Request) {
+	membership, ok := cfg.bandAccess(w, r)
+	if !ok {
+		return
+	}
+
+	custom, err := cfg.db.GetBandStageSymbols(r.Context(), membership.BandID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	res := make([]map[string]any, 0, len(symbol.Library)+len(custom))
+	for _, s := range custom {
+		res = append(res, stageSymbolResponse(s.ItemType, "custom", s.Svg))
+	}
+	types := make([]string, 0, len(symbol.Library))
+	for t := range symbol.Library {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+	for _, t := range types {
+		res = append(res, stageSymbolResponse(t, "library", string(symbol.Library[t].SVG())))
+	}
+	RespondWithJSON(w, http.StatusOK, res)
+}
+
+// PutStageSymbol uploads the SVG in the request body as the band's symbol for
+// a stage plot item type. The file is reduced to its shapes before it's
+// stored, so nothing else in it ever reaches a browser.
+func (cfg *config) PutStageSymbol(w http.ResponseWriter, r *http.Request) {
+	membership, ok := cfg.bandAccess(w, r)
+	if !ok {
+		return
+	}
+
+	itemType := symbol.Key(r.PathValue("item_type"))
+	if !symbol.ValidKey(itemType) {
+		RespondWithError(w, http.StatusBadRequest, "item types can only use letters, numbers and dashes, up to 40 characters")
+		return
+	}
+
+	raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSymbolBytes))
+	if err != nil {
+		RespondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("symbols can be at most %d KB", maxSymbolBytes>>10))
+		return
+	}
+
+	sym, err := symbol.Parse(raw)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	saved, err := cfg.db.UpsertStageSymbol(r.Context(), database.UpsertStageSymbolParams{
+		BandID:   membership.BandID,
+		ItemType: itemType,
+		Svg:      string(sym.SVG()),
+	})
+	if err != nil {
+		log.Printf("failed to store stage symbol: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, stageSymbolResponse(saved.ItemType, "custom", saved.Svg))
+}
+
+// DeleteStageSymbol removes a band's custom symbol, putting the library's
+// back in its place if there is one.
+func (cfg *config) DeleteStageSymbol(w http.ResponseWriter, r *http.Request) {
+	membership, ok := cfg.bandAccess(w, r)
+	if !ok {
+		return
+	}
+
+	deleted, err := cfg.db.DeleteStageSymbol(r.Context(), database.DeleteStageSymbolParams{
+		BandID:   membership.BandID,
+		ItemType: symbol.Key(r.PathValue("item_type")),
+	})
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	} else if deleted == 0 {
+		RespondWithError(w, http.StatusNotFound, "no matching custom symbol")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// bandSymbols loads a band's custom stage plot symbols. A stored symbol that
+// no longer parses is logged and left out, so the plot falls back to the
+// built in symbol rather than failing.
+func (cfg *config) bandSymbols(ctx context.Context, bandID int32) (symbol.Set, error) {
+	custom, err := cfg.db.GetBandStageSymbols(ctx, bandID)
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(symbol.Set, len(custom))
+	for _, s := range custom {
+		sym, err := symbol.Parse([]byte(s.Svg))
+		if err != nil {
+			log.Printf("skipping stored symbol %q for band %d: %v", s.ItemType, bandID, err)
+			continue
+		}
+		set[s.ItemType] = sym
+	}
+	return set, nil
+}
+
+func stageSymbolResponse(itemType, source, svg string) map[string]any {
+	return map[string]any{
+		"item_type": itemType,
+		"source":    source,
+		"svg":       svg,
+	}
+}
@@ -0,0 +1,496 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/tour"
+)
+
+type tourBody struct {
+	BandID   int32  `json:"band_id"`
+	Name     string `json:"name"`
+	StartsOn string `json:"starts_on"`
+	EndsOn   string `json:"ends_on"`
+	Notes    string `json:"notes"`
+}
+
+func (b tourBody) parse() (database.CreateTourParams, error) {
+	params := database.CreateTourParams{
+		BandID: b.BandID,
+		Name:   strings.TrimSpace(b.Name),
+		Notes:  b.Notes,
+	}
+	if params.Name == "" {
+		return params, errors.New("tour name is required")
+	}
+
+	var err error
+	params.StartsOn, err = time.Parse(dateLayout, b.StartsOn)
+	if err != nil {
+		return params, fmt.Errorf("starts_on must look like %s", dateLayout)
+	}
+	params.EndsOn, err = time.Parse(dateLayout, b.EndsOn)
+	if err != nil {
+		return params, fmt.Errorf("ends_on must look like %s", dateLayout)
+	} else if params.EndsOn.Before(params.StartsOn) {
+		return params, errors.New("a tour can't end before it starts")
+	} else if params.EndsOn.After(params.StartsOn.AddDate(0, 0, tour.MaxDays-1)) {
+		return params, fmt.Errorf("a tour can run for at most %d days", tour.MaxDays)
+	}
+	return params, nil
+}
+
+// checkShowTour makes sure a show is only put on one of its own band's
+// tours.
+func (cfg *config) checkShowTour(w http.ResponseWriter, r *http.Request, bandID int32, tourID sql.NullInt32) bool {
+	if !tourID.Valid {
+		return true
+	}
+
+	t, err := cfg.db.GetTour(r.Context(), tourID.Int32)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && t.BandID != bandID) {
+		RespondWithError(w, http.StatusBadRequest, "no matching tour")
+		return false
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return false
+	}
+	return true
+}
+
+func (cfg *config) CreateTour(w http.ResponseWriter, r *http.Request) {
+	var body tourBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	_, ok := cfg.membership(w, r, body.BandID)
+	if !ok {
+		return
+	}
+
+	params, err := body.parse()
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	t, err := cfg.db.CreateTour(r.Context(), params)
+	if err != nil {
+		log.Printf("failed to create tour: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, tourResponse(t))
+}
+
+func (cfg *config) GetBandTours(w http.ResponseWriter, r *http.Request) {
+	membership, ok := cfg.bandAccess(w, r)
+	if !ok {
+		return
+	}
+
+	tours, err := cfg.db.GetBandTours(r.Context(), membership.BandID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	res := make([]map[string]any, 0, len(tours))
+	for _, t := range tours {
+		res = append(res, tourResponse(t))
+	}
+	RespondWithJSON(w, http.StatusOK, res)
+}
+
+// GetTour returns a tour along with the people going on it and its shows.
+func (cfg *config) GetTour(w http.ResponseWriter, r *http.Request) {
+	t, _, ok := cfg.tourAccess(w, r)
+	if !ok {
+		return
+	}
+
+	people, err := cfg.db.GetTourPeople(r.Context(), t.ID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	shows, err := cfg.db.GetTourShows(r.Context(), sql.NullInt32{Int32: t.ID, Valid: true})
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	res := tourResponse(t)
+	res["people"] = tourPeopleResponse(people)
+	showsRes := make([]map[string]any, 0, len(shows))
+	for _, show := range shows {
+		showsRes = append(showsRes, showResponse(show))
+	}
+	res["shows"] = showsRes
+	RespondWithJSON(w, http.StatusOK, res)
+}
+
+func (cfg *config) UpdateTour(w http.ResponseWriter, r *http.Request) {
+	t, _, ok := cfg.tourAccess(w, r)
+	if !ok {
+		return
+	}
+
+	var body tourBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+	// tours can't be moved between bands
+	body.BandID = t.BandID
+
+	params, err := body.parse()
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated, err := cfg.db.UpdateTour(r.Context(), database.UpdateTourParams{
+		ID:       t.ID,
+		Name:     params.Name,
+		StartsOn: params.StartsOn,
+		EndsOn:   params.EndsOn,
+		Notes:    params.Notes,
+	})
+	if err != nil {
+		log.Printf("failed to update tour: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, tourResponse(updated))
+}
+
+// DeleteTour removes a tour. Its shows stay on the band's calendar, just no
+// longer grouped under it.
+func (cfg *config) DeleteTour(w http.ResponseWriter, r *http.Request) {
+	t, membership, ok := cfg.tourAccess(w, r)
+	if !ok {
+		return
+	} else if !membership.AccountIsAdmin {
+		RespondWithError(w, http.StatusForbidden, "only band admins can delete a tour")
+		return
+	}
+
+	err := cfg.db.DeleteTour(r.Context(), t.ID)
+	if err != nil {
+		log.Printf("failed to delete tour: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddTourPerson puts someone on a tour. Band members are added by account
+// and crew without an account by name.
+func (cfg *config) AddTourPerson(w http.ResponseWriter, r *http.Request) {
+	t, _, ok := cfg.tourAccess(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		AccountID int32  `json:"account_id"`
+		Name      string `json:"name"`
+		Role      string `json:"role"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	params := database.CreateTourPersonParams{
+		TourID: t.ID,
+		Name:   strings.TrimSpace(body.Name),
+		Role:   strings.TrimSpace(body.Role),
+	}
+	name := params.Name
+	if body.AccountID != 0 {
+		account, err := cfg.db.GetAccount(r.Context(), body.AccountID)
+		if err == nil {
+			_, err = cfg.db.GetAccountBand(r.Context(), database.GetAccountBandParams{
+				AccountID: body.AccountID,
+				BandID:    t.BandID,
+			})
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			RespondWithError(w, http.StatusBadRequest, "only members of the band can be added by account")
+			return
+		} else if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+			return
+		}
+
+		people, err := cfg.db.GetTourPeople(r.Context(), t.ID)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+			return
+		}
+		for _, p := range people {
+			if p.AccountID.Valid && p.AccountID.Int32 == body.AccountID {
+				RespondWithError(w, http.StatusConflict, "that member is already on this tour")
+				return
+			}
+		}
+
+		// the account's own name is shown, so a copy that could go stale
+		// isn't kept
+		params.AccountID = sql.NullInt32{Int32: body.AccountID, Valid: true}
+		params.Name = ""
+		name = strings.TrimSpace(account.GivenName + " " + account.FamilyName)
+	} else if params.Name == "" {
+		RespondWithError(w, http.StatusBadRequest, "crew need a name, or a band member's account_id")
+		return
+	}
+
+	person, err := cfg.db.CreateTourPerson(r.Context(), params)
+	if err != nil {
+		log.Printf("failed to add tour person: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, tourPeopleResponse([]database.GetTourPeopleRow{{
+		ID:        person.ID,
+		TourID:    person.TourID,
+		AccountID: person.AccountID,
+		Role:      person.Role,
+		CreatedAt: person.CreatedAt,
+		Name:      name,
+	}})[0])
+}
+
+func (cfg *config) RemoveTourPerson(w http.ResponseWriter, r *http.Request) {
+	t, _, ok := cfg.tourAccess(w, r)
+	if !ok {
+		return
+	}
+
+	personID, err := strconv.Atoi(r.PathValue("person_id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "invalid person id")
+		return
+	}
+
+	deleted, err := cfg.db.DeleteTourPerson(r.Context(), database.DeleteTourPersonParams{
+		ID:     int32(personID),
+		TourID: t.ID,
+	})
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	} else if deleted == 0 {
+		RespondWithError(w, http.StatusNotFound, "no matching person on this tour")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetTourRouting lays the tour out day by day, with the distance between
+// consecutive shows, the off days and anything that looks wrong.
+func (cfg *config) GetTourRouting(w http.ResponseWriter, r *http.Request) {
+	t, _, ok := cfg.tourAccess(w, r)
+	if !ok {
+		return
+	}
+
+	shows, err := cfg.db.GetTourShows(r.Context(), sql.NullInt32{Int32: t.ID, Valid: true})
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	stops := make([]tour.Stop, 0, len(shows))
+	for _, show := range shows {
+		stop := tour.Stop{
+			ShowID:    show.ID,
+			Date:      show.Date,
+			Venue:     show.VenueName,
+			City:      show.City,
+			Status:    string(show.Status),
+			Cancelled: show.Status == database.ShowStatusCancelled,
+		}
+		if show.Latitude.Valid && show.Longitude.Valid {
+			stop.Location = &tour.Point{Latitude: show.Latitude.Float64, Longitude: show.Longitude.Float64}
+		}
+		stops = append(stops, stop)
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]any{
+		"tour":    tourResponse(t),
+		"routing": tour.Route(t.StartsOn, t.EndsOn, stops),
+	})
+}
+
+// ImportTourShows creates the tour's shows from a routing spreadsheet sent
+// as the request body, as CSV or XLSX. Like an input list import, ?dry_run=true
+// only reports what was found, and nothing is written unless every row reads
+// cleanly.
+func (cfg *config) ImportTourShows(w http.ResponseWriter, r *http.Request) {
+	t, _, ok := cfg.tourAccess(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	dryRun, _ := strconv.ParseBool(query.Get("dry_run"))
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportBytes))
+	if err != nil {
+		RespondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("imports can be at most %d MB", maxImportBytes>>20))
+		return
+	}
+
+	rows, err := readSpreadsheet(data, query.Get("format"), r.Header.Get("Content-Type"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result := tour.ImportShows(rows)
+	if dryRun {
+		RespondWithJSON(w, http.StatusOK, map[string]any{
+			"import":    importResponse(result),
+			"committed": false,
+		})
+		return
+	} else if len(result.Errors) > 0 {
+		RespondWithJSON(w, http.StatusUnprocessableEntity, map[string]any{
+			"message":   "fix the rows with errors and try again",
+			"import":    importResponse(result),
+			"committed": false,
+		})
+		return
+	}
+
+	tx, err := cfg.conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	created := make([]map[string]any, 0, len(result.Shows))
+	for _, imported := range result.Shows {
+		body := showBody{
+			BandID:    t.BandID,
+			Date:      imported.Date.Format(dateLayout),
+			VenueName: imported.Venue,
+			City:      imported.City,
+			Timezone:  imported.Timezone,
+			Status:    imported.Status,
+			TourID:    t.ID,
+		}
+		if imported.Location != nil {
+			body.Latitude, body.Longitude = &imported.Location.Latitude, &imported.Location.Longitude
+		}
+
+		params, err := body.parse()
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("row %d: %v", imported.Row, err))
+			return
+		}
+
+		show, err := qtx.CreateShow(r.Context(), params)
+		if err != nil {
+			log.Printf("failed to create imported show: %v", err)
+			RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+			return
+		}
+		created = append(created, showResponse(show))
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, map[string]any{
+		"import":    importResponse(result),
+		"committed": true,
+		"shows":     created,
+	})
+}
+
+func importResponse(result tour.ShowImport) map[string]any {
+	shows := make([]map[string]any, 0, len(result.Shows))
+	for _, show := range result.Shows {
+		shows = append(shows, map[string]any{
+			"row":      show.Row,
+			"date":     show.Date.Format(dateLayout),
+			"venue":    show.Venue,
+			"city":     show.City,
+			"timezone": show.Timezone,
+			"location": show.Location,
+			"status":   show.Status,
+		})
+	}
+
+	return map[string]any{
+		"layout":     result.Layout,
+		"header_row": result.HeaderRow,
+		"shows":      shows,
+		"errors":     result.Errors,
+	}
+}
+
+func tourResponse(t database.Tour) map[string]any {
+	return map[string]any{
+		"id":         t.ID,
+		"band_id":    t.BandID,
+		"name":       t.Name,
+		"starts_on":  t.StartsOn.Format(dateLayout),
+		"ends_on":    t.EndsOn.Format(dateLayout),
+		"notes":      t.Notes,
+		"created_at": t.CreatedAt,
+		"updated_at": t.UpdatedAt,
+	}
+}
+
+func tourPeopleResponse(people []database.GetTourPeopleRow) []map[string]any {
+	res := make([]map[string]any, 0, len(people))
+	for _, p := range people {
+		var accountID *int32
+		kind := "crew"
+		if p.AccountID.Valid {
+			accountID = &p.AccountID.Int32
+			kind = "member"
+		}
+		res = append(res, map[string]any{
+			"id":         p.ID,
+			"tour_id":    p.TourID,
+			"account_id": accountID,
+			"kind":       kind,
+			"name":       p.Name,
+			"role":       p.Role,
+			"created_at": p.CreatedAt,
+		})
+	}
+	return res
+}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/jkellogg01/rider/server/database"
 	"github.com/jkellogg01/rider/server/jwt"
@@ -146,9 +147,49 @@ func (cfg *config) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	RespondWithJSON(w, http.StatusOK, map[string]any{
-		"id":         user.ID,
-		"email":      user.Email,
-		"givenName":  user.GivenName,
-		"familyName": user.FamilyName,
+		"id":                  user.ID,
+		"email":               user.Email,
+		"givenName":           user.GivenName,
+		"familyName":          user.FamilyName,
+		"dietaryRestrictions": user.DietaryRestrictions,
+	})
+}
+
+func (cfg *config) UpdateCurrentUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := r.Context().Value("current-user").(int)
+	if !ok {
+		RespondWithError(w, http.StatusInternalServerError, "invalid or missing user id")
+		return
+	}
+
+	var body struct {
+		DietaryRestrictions string `json:"dietaryRestrictions"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		log.Printf("failed to decode request body: %v", err)
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	user, err := cfg.db.UpdateAccountDietaryRestrictions(r.Context(), database.UpdateAccountDietaryRestrictionsParams{
+		ID:                  int32(id),
+		DietaryRestrictions: strings.TrimSpace(body.DietaryRestrictions),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		RespondWithError(w, http.StatusNotFound, "failed to find user")
+		return
+	} else if err != nil {
+		log.Printf("database error: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]any{
+		"id":                  user.ID,
+		"email":               user.Email,
+		"givenName":           user.GivenName,
+		"familyName":          user.FamilyName,
+		"dietaryRestrictions": user.DietaryRestrictions,
 	})
 }
@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/tour"
+	"github.com/jkellogg01/rider/server/venue"
+)
+
+type venueBody struct {
+	BandID   int32      `json:"band_id"`
+	Name     string     `json:"name"`
+	City     string     `json:"city"`
+	Address  string     `json:"address"`
+	Timezone string     `json:"timezone"`
+	Spec     venue.Spec `json:"spec"`
+	// Latitude and Longitude are optional, and only used to measure the
+	// drive between shows on a tour.
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+func (b venueBody) parse() (database.CreateVenueParams, error) {
+	params := database.CreateVenueParams{
+		BandID:   b.BandID,
+		Name:     strings.TrimSpace(b.Name),
+		City:     strings.TrimSpace(b.City),
+		Address:  strings.TrimSpace(b.Address),
+		Timezone: b.Timezone,
+	}
+	if params.Name == "" {
+		return params, errors.New("venue name is required")
+	}
+
+	if params.Timezone == "" {
+		params.Timezone = "UTC"
+	}
+	_, err := time.LoadLocation(params.Timezone)
+	if err != nil {
+		return params, fmt.Errorf("unknown timezone %q", params.Timezone)
+	}
+
+	params.Latitude, params.Longitude, err = parseCoordinates(b.Latitude, b.Longitude)
+	if err != nil {
+		return params, err
+	}
+
+	err = b.Spec.Validate()
+	if err != nil {
+		return params, err
+	}
+	params.Spec, err = json.Marshal(b.Spec)
+	return params, err
+}
+
+func (cfg *config) CreateVenue(w http.ResponseWriter, r *http.Request) {
+	var body venueBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	params, err := body.parse()
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, ok := cfg.membership(w, r, params.BandID)
+	if !ok {
+		return
+	}
+
+	v, err := cfg.db.CreateVenue(r.Context(), params)
+	if err != nil {
+		log.Printf("failed to create venue: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, venueResponse(v))
+}
+
+func (cfg *config) GetBandVenues(w http.ResponseWriter, r *http.Request) {
+	membership, ok := cfg.bandAccess(w, r)
+	if !ok {
+		return
+	}
+
+	venues, err := cfg.db.GetBandVenues(r.Context(), membership.BandID)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return
+	}
+
+	res := make([]map[string]any, 0, len(venues))
+	for _, v := range venues {
+		res = append(res, venueResponse(v))
+	}
+	RespondWithJSON(w, http.StatusOK, res)
+}
+
+func (cfg *config) GetVenue(w http.ResponseWriter, r *http.Request) {
+	v, _, ok := cfg.venueAccess(w, r)
+	if !ok {
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, venueResponse(v))
+}
+
+func (cfg *config) UpdateVenue(w http.ResponseWriter, r *http.Request) {
+	v, _, ok := cfg.venueAccess(w, r)
+	if !ok {
+		return
+	}
+
+	var body venueBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+	// venues can't be moved between bands
+	body.BandID = v.BandID
+
+	params, err := body.parse()
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated, err := cfg.db.UpdateVenue(r.Context(), database.UpdateVenueParams{
+		ID:        v.ID,
+		Name:      params.Name,
+		City:      params.City,
+		Address:   params.Address,
+		Timezone:  params.Timezone,
+		Spec:      params.Spec,
+		Latitude:  params.Latitude,
+		Longitude: params.Longitude,
+	})
+	if err != nil {
+		log.Printf("failed to update venue: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, venueResponse(updated))
+}
+
+// DeleteVenue removes a venue. Shows booked there are unlinked from it but
+// keep the venue name and city they were saved with.
+func (cfg *config) DeleteVenue(w http.ResponseWriter, r *http.Request) {
+	v, membership, ok := cfg.venueAccess(w, r)
+	if !ok {
+		return
+	} else if !membership.AccountIsAdmin {
+		RespondWithError(w, http.StatusForbidden, "only band admins can delete a venue")
+		return
+	}
+
+	err := cfg.db.DeleteVenue(r.Context(), v.ID)
+	if err != nil {
+		log.Printf("failed to delete venue: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// showVenue fills in the name, city and timezone of a show booked at one of
+// the band's venues wherever the request left them out.
+func (cfg *config) showVenue(w http.ResponseWriter, r *http.Request, bandID int32, body *showBody) bool {
+	if body.VenueID == 0 {
+		return true
+	}
+
+	v, err := cfg.db.GetVenue(r.Context(), body.VenueID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && v.BandID != bandID) {
+		RespondWithError(w, http.StatusBadRequest, "no matching venue")
+		return false
+	} else if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "unexpected database error")
+		return false
+	}
+
+	if strings.TrimSpace(body.VenueName) == "" {
+		body.VenueName = v.Name
+	}
+	if strings.TrimSpace(body.City) == "" {
+		body.City = v.City
+	}
+	if body.Timezone == "" {
+		body.Timezone = v.Timezone
+	}
+	if body.Latitude == nil && body.Longitude == nil && v.Latitude.Valid && v.Longitude.Valid {
+		body.Latitude, body.Longitude = &v.Latitude.Float64, &v.Longitude.Float64
+	}
+	return true
+}
+
+// parseCoordinates checks an optional latitude and longitude, which have to
+// be given together.
+func parseCoordinates(lat, lng *float64) (sql.NullFloat64, sql.NullFloat64, error) {
+	if lat == nil && lng == nil {
+		return sql.NullFloat64{}, sql.NullFloat64{}, nil
+	} else if lat == nil || lng == nil {
+		return sql.NullFloat64{}, sql.NullFloat64{}, errors.New("latitude and longitude have to be given together")
+	} else if !(tour.Point{Latitude: *lat, Longitude: *lng}).Valid() {
+		return sql.NullFloat64{}, sql.NullFloat64{}, errors.New("latitude must be within 90 degrees and longitude within 180")
+	}
+	return sql.NullFloat64{Float64: *lat, Valid: true}, sql.NullFloat64{Float64: *lng, Valid: true}, nil
+}
+
+func nullFloat(f sql.NullFloat64) *float64 {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
+
+func venueResponse(v database.Venue) map[string]any {
+	return map[string]any{
+		"id":         v.ID,
+		"band_id":    v.BandID,
+		"name":       v.Name,
+		"city":       v.City,
+		"address":    v.Address,
+		"timezone":   v.Timezone,
+		"latitude":   nullFloat(v.Latitude),
+		"longitude":  nullFloat(v.Longitude),
+		"spec":       v.Spec,
+		"created_at": v.CreatedAt,
+		"updated_at": v.UpdatedAt,
+	}
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"slices"
+
+	"github.com/jkellogg01/rider/server/database"
+	"github.com/jkellogg01/rider/server/rider"
+)
+
+const maxWirelessBytes = 1 << 20
+
+// CheckWireless reports conflicts between the frequencies assigned in a
+// wireless setup without saving anything, so a tech can try out changes.
+func (cfg *config) CheckWireless(w http.ResponseWriter, r *http.Request) {
+	var body rider.Wireless
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWirelessBytes)).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	err = rider.Document{Wireless: &body}.Validate()
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]any{
+		"conflicts": body.Conflicts(),
+	})
+}
+
+// PlanShowWireless coordinates frequencies for the wireless devices on a
+// show's rider, avoiding any extra exclusions for the venue, and saves the
+// frequencies into the show's overrides so that they go out with the rider.
+// Devices that are neither a mic on a channel nor an IEM on a mix can't be
+// keyed, so they are planned but not saved.
+func (cfg *config) PlanShowWireless(w http.ResponseWriter, r *http.Request) {
+	show, _, ok := cfg.showAccess(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Exclusions []rider.FrequencyRange `json:"exclusions"`
+		// Replan throws away frequencies that were already assigned.
+		Replan bool `json:"replan"`
+	}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWirelessBytes)).Decode(&body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	_, doc, ok := cfg.showRider(w, r, show)
+	if !ok {
+		return
+	} else if doc.Wireless == nil || len(doc.Wireless.Devices) == 0 {
+		RespondWithError(w, http.StatusConflict, "this show's rider has no wireless devices")
+		return
+	}
+
+	wireless := *doc.Wireless
+	if body.Replan {
+		wireless.Devices = append([]rider.WirelessDevice(nil), wireless.Devices...)
+		for i := range wireless.Devices {
+			wireless.Devices[i].Frequency = 0
+		}
+	}
+	// the venue's exclusions are checked before planning, since planning
+	// walks every frequency in them
+	check := wireless
+	check.Exclusions = append(slices.Clone(wireless.Exclusions), body.Exclusions...)
+	err = rider.Document{Wireless: &check}.Validate()
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	planned, unplaced := wireless.Coordinate(body.Exclusions)
+
+	doc.Wireless = planned
+	err = doc.Validate()
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// only the frequencies are saved, keyed by device, so the rest of the
+	// wireless setup keeps following the rider
+	overrides := map[string]any{}
+	err = json.Unmarshal(show.RiderOverrides, &overrides)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to decode show overrides")
+		return
+	}
+	wirelessOverrides, _ := overrides["wireless"].(map[string]any)
+	if wirelessOverrides == nil {
+		wirelessOverrides = map[string]any{}
+	}
+	devices, _ := wirelessOverrides["devices"].(map[string]any)
+	if devices == nil {
+		devices = map[string]any{}
+	}
+	for _, device := range planned.Devices {
+		key := device.Key()
+		if key == "" {
+			continue
+		}
+		change, _ := devices[key].(map[string]any)
+		if change == nil {
+			change = map[string]any{}
+		}
+		change["frequency"] = device.Frequency
+		devices[key] = change
+	}
+	wirelessOverrides["devices"] = devices
+	overrides["wireless"] = wirelessOverrides
+	encoded, err := json.Marshal(overrides)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "failed to encode show overrides")
+		return
+	}
+
+	_, err = cfg.db.UpdateShowOverrides(r.Context(), database.UpdateShowOverridesParams{
+		ID:             show.ID,
+		RiderOverrides: encoded,
+	})
+	if err != nil {
+		log.Printf("failed to save wireless plan: %v", err)
+		RespondWithError(w, http.StatusInternalServerError, "failed to write to database")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]any{
+		"wireless":  planned,
+		"unplaced":  unplaced,
+		"conflicts": planned.Conflicts(),
+	})
+}
@@ -0,0 +1,250 @@
+// Package ical writes RFC 5545 calendars. Event times are written as local
+// times against a VTIMEZONE generated from the Go timezone database, so
+// calendar apps show them in the venue's timezone no matter where the reader
+// is.
+package ical
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	localLayout = "20060102T150405"
+	utcLayout   = "20060102T150405Z"
+	dateLayout  = "20060102"
+)
+
+type Status string
+
+const (
+	StatusTentative Status = "TENTATIVE"
+	StatusConfirmed Status = "CONFIRMED"
+	StatusCancelled Status = "CANCELLED"
+)
+
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+type Event struct {
+	// UID must stay the same for the life of the event so that updates and
+	// cancellations replace the copy a subscriber already has.
+	UID string
+	// Sequence must increase every time the event changes.
+	Sequence     int
+	Summary      string
+	Description  string
+	Location     string
+	Status       Status
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	Created      time.Time
+	LastModified time.Time
+}
+
+func (c Calendar) Write(w io.Writer) error {
+	cw := &contentWriter{w: w}
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:-//rider//shows//EN")
+	cw.line("CALSCALE:GREGORIAN")
+	cw.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		cw.property("X-WR-CALNAME", escape(c.Name))
+	}
+
+	for _, loc := range timezones(c.Events) {
+		writeTimezone(cw, loc, c.Events)
+	}
+
+	stamp := time.Now().UTC().Format(utcLayout)
+	for _, e := range c.Events {
+		cw.line("BEGIN:VEVENT")
+		cw.property("UID", escape(e.UID))
+		cw.property("DTSTAMP", stamp)
+		cw.property("SEQUENCE", fmt.Sprint(e.Sequence))
+		if e.AllDay {
+			cw.property("DTSTART;VALUE=DATE", e.Start.Format(dateLayout))
+			cw.property("DTEND;VALUE=DATE", e.End.Format(dateLayout))
+		} else {
+			cw.property(timeProperty("DTSTART", e.Start))
+			cw.property(timeProperty("DTEND", e.End))
+		}
+		cw.property("SUMMARY", escape(e.Summary))
+		if e.Description != "" {
+			cw.property("DESCRIPTION", escape(e.Description))
+		}
+		if e.Location != "" {
+			cw.property("LOCATION", escape(e.Location))
+		}
+		if e.Status != "" {
+			cw.property("STATUS", string(e.Status))
+		}
+		if !e.Created.IsZero() {
+			cw.property("CREATED", e.Created.UTC().Format(utcLayout))
+		}
+		if !e.LastModified.IsZero() {
+			cw.property("LAST-MODIFIED", e.LastModified.UTC().Format(utcLayout))
+		}
+		cw.line("END:VEVENT")
+	}
+
+	cw.line("END:VCALENDAR")
+	return cw.err
+}
+
+func timeProperty(name string, t time.Time) (string, string) {
+	if t.Location() == time.UTC {
+		return name, t.Format(utcLayout)
+	}
+	return fmt.Sprintf("%s;TZID=%s", name, t.Location().String()), t.Format(localLayout)
+}
+
+// timezones lists the distinct non-UTC locations the timed events use.
+func timezones(events []Event) []*time.Location {
+	var locs []*time.Location
+	for _, e := range events {
+		loc := e.Start.Location()
+		if e.AllDay || loc == time.UTC {
+			continue
+		}
+		known := slices.ContainsFunc(locs, func(l *time.Location) bool {
+			return l.String() == loc.String()
+		})
+		if !known {
+			locs = append(locs, loc)
+		}
+	}
+	return locs
+}
+
+// writeTimezone describes loc with one observance per offset change in the
+// years the events span, plus the year before so that the first event is
+// always covered by an observance that started before it.
+func writeTimezone(cw *contentWriter, loc *time.Location, events []Event) {
+	first, last := 0, 0
+	for _, e := range events {
+		if e.AllDay || e.Start.Location().String() != loc.String() {
+			continue
+		}
+		for _, t := range []time.Time{e.Start, e.End} {
+			if first == 0 || t.Year() < first {
+				first = t.Year()
+			}
+			last = max(last, t.Year())
+		}
+	}
+
+	from := time.Date(first-1, time.January, 1, 0, 0, 0, 0, loc)
+	until := time.Date(last+1, time.January, 1, 0, 0, 0, 0, loc)
+
+	cw.line("BEGIN:VTIMEZONE")
+	cw.property("TZID", loc.String())
+	transitions := transitionsBetween(from, until)
+	if len(transitions) == 0 {
+		name, offset := from.Zone()
+		writeObservance(cw, "STANDARD", from, name, offset, offset)
+	}
+	for _, t := range transitions {
+		_, before := t.Add(-time.Second).Zone()
+		name, after := t.Zone()
+		kind := "STANDARD"
+		if t.IsDST() {
+			kind = "DAYLIGHT"
+		}
+		// the onset is written in the local time that was in effect before it
+		writeObservance(cw, kind, t.UTC().Add(time.Duration(before)*time.Second), name, before, after)
+	}
+	cw.line("END:VTIMEZONE")
+}
+
+func writeObservance(cw *contentWriter, kind string, onset time.Time, name string, from, to int) {
+	cw.line("BEGIN:" + kind)
+	cw.property("DTSTART", onset.Format(localLayout))
+	cw.property("TZOFFSETFROM", formatOffset(from))
+	cw.property("TZOFFSETTO", formatOffset(to))
+	cw.property("TZNAME", escape(name))
+	cw.line("END:" + kind)
+}
+
+// transitionsBetween finds every instant the UTC offset changes. Offsets are
+// sampled daily and each change is then narrowed down to the second, which
+// is cheap enough for the handful of years a calendar covers.
+func transitionsBetween(from, until time.Time) []time.Time {
+	var transitions []time.Time
+	_, offset := from.Zone()
+	for day := from; day.Before(until); day = day.Add(24 * time.Hour) {
+		next := day.Add(24 * time.Hour)
+		_, nextOffset := next.Zone()
+		if nextOffset == offset {
+			continue
+		}
+		lo, hi := day, next
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if _, o := mid.Zone(); o == offset {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		transitions = append(transitions, hi.Truncate(time.Second))
+		offset = nextOffset
+	}
+	return transitions
+}
+
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// contentWriter folds content lines at 75 octets without splitting a
+// multi-byte character, and holds on to the first write error.
+type contentWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (cw *contentWriter) property(name, value string) {
+	cw.line(name + ":" + value)
+}
+
+func (cw *contentWriter) line(s string) {
+	if cw.err != nil {
+		return
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+	_, cw.err = io.WriteString(cw.w, b.String())
+}
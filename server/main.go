@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	_ "time/tzdata"
 
 	"github.com/jkellogg01/rider/server/handler"
 	"github.com/jkellogg01/rider/server/middleware/authentication"
@@ -35,11 +36,98 @@ func main() {
 	authed := http.NewServeMux()
 	api.Handle("/", authentication.AuthenticateUser(authed))
 	authed.HandleFunc("GET /me", cfg.GetCurrentUser)
+	authed.HandleFunc("PATCH /me", cfg.UpdateCurrentUser)
+	authed.HandleFunc("GET /me/calendar", cfg.GetCalendarFeed)
+	authed.HandleFunc("POST /me/calendar", cfg.RotateCalendarFeed)
 	authed.HandleFunc("GET /bands", cfg.GetUserBands)
 	authed.HandleFunc("GET /bands/{band_id}", cfg.GetBand)
 	authed.HandleFunc("POST /bands", cfg.CreateBand)
 	authed.HandleFunc("GET /bands/join/{band_id}", cfg.CreateInvitation)
 	authed.HandleFunc("POST /bands/join", cfg.RedeemInvitation)
+	authed.HandleFunc("GET /riders", cfg.GetBandRiders)
+	authed.HandleFunc("POST /riders", cfg.CreateRider)
+	authed.HandleFunc("GET /riders/{rider_id}", cfg.GetRider)
+	authed.HandleFunc("PUT /riders/{rider_id}", cfg.UpdateRider)
+	authed.HandleFunc("GET /riders/{rider_id}/revisions", cfg.GetRiderRevisions)
+	authed.HandleFunc("GET /riders/{rider_id}/published", cfg.GetPublishedRider)
+	authed.HandleFunc("POST /riders/{rider_id}/submit", cfg.SubmitRider)
+	authed.HandleFunc("POST /riders/{rider_id}/publish", cfg.PublishRider)
+	authed.HandleFunc("GET /riders/{rider_id}/pdf", cfg.GetRiderPDF)
+	authed.HandleFunc("GET /riders/{rider_id}/share-links", cfg.GetShareLinks)
+	authed.HandleFunc("POST /riders/{rider_id}/share-links", cfg.CreateShareLink)
+	authed.HandleFunc("DELETE /riders/{rider_id}/share-links/{link_id}", cfg.RevokeShareLink)
+	authed.HandleFunc("GET /riders/{rider_id}/advance", cfg.GetRiderAdvance)
+	authed.HandleFunc("GET /riders/{rider_id}/power", cfg.GetRiderPower)
+	authed.HandleFunc("GET /riders/{rider_id}/input-list", cfg.GetRiderInputList)
+	authed.HandleFunc("GET /riders/{rider_id}/console/{console}", cfg.GetRiderConsoleScene)
+	authed.HandleFunc("GET /riders/{rider_id}/patch", cfg.GetRiderPatch)
+	authed.HandleFunc("POST /riders/{rider_id}/input-list/import", cfg.ImportInputList)
+	authed.HandleFunc("GET /gear", cfg.GetBandGear)
+	authed.HandleFunc("POST /gear", cfg.CreateGear)
+	authed.HandleFunc("GET /gear/{gear_id}", cfg.GetGear)
+	authed.HandleFunc("PUT /gear/{gear_id}", cfg.UpdateGear)
+	authed.HandleFunc("DELETE /gear/{gear_id}", cfg.DeleteGear)
+	authed.HandleFunc("POST /gear/{gear_id}/photos", cfg.AddGearPhoto)
+	authed.HandleFunc("GET /gear/{gear_id}/photos/{photo_id}", cfg.GetGearPhoto)
+	authed.HandleFunc("DELETE /gear/{gear_id}/photos/{photo_id}", cfg.DeleteGearPhoto)
+	authed.HandleFunc("GET /venues", cfg.GetBandVenues)
+	authed.HandleFunc("POST /venues", cfg.CreateVenue)
+	authed.HandleFunc("GET /venues/{venue_id}", cfg.GetVenue)
+	authed.HandleFunc("PUT /venues/{venue_id}", cfg.UpdateVenue)
+	authed.HandleFunc("DELETE /venues/{venue_id}", cfg.DeleteVenue)
+	authed.HandleFunc("GET /stage-symbols", cfg.GetStageSymbols)
+	authed.HandleFunc("PUT /stage-symbols/{item_type}", cfg.PutStageSymbol)
+	authed.HandleFunc("DELETE /stage-symbols/{item_type}", cfg.DeleteStageSymbol)
+	authed.HandleFunc("GET /tours", cfg.GetBandTours)
+	authed.HandleFunc("POST /tours", cfg.CreateTour)
+	authed.HandleFunc("GET /tours/{tour_id}", cfg.GetTour)
+	authed.HandleFunc("PUT /tours/{tour_id}", cfg.UpdateTour)
+	authed.HandleFunc("DELETE /tours/{tour_id}", cfg.DeleteTour)
+	authed.HandleFunc("POST /tours/{tour_id}/people", cfg.AddTourPerson)
+	authed.HandleFunc("DELETE /tours/{tour_id}/people/{person_id}", cfg.RemoveTourPerson)
+	authed.HandleFunc("GET /tours/{tour_id}/routing", cfg.GetTourRouting)
+	authed.HandleFunc("POST /tours/{tour_id}/shows/import", cfg.ImportTourShows)
+	authed.HandleFunc("GET /shows", cfg.GetBandShows)
+	authed.HandleFunc("POST /shows", cfg.CreateShow)
+	authed.HandleFunc("GET /shows/{show_id}", cfg.GetShow)
+	authed.HandleFunc("PUT /shows/{show_id}", cfg.UpdateShow)
+	authed.HandleFunc("GET /shows/{show_id}/overrides", cfg.GetShowOverrides)
+	authed.HandleFunc("PUT /shows/{show_id}/overrides", cfg.UpdateShowOverrides)
+	authed.HandleFunc("GET /shows/{show_id}/rider", cfg.GetShowRider)
+	authed.HandleFunc("GET /shows/{show_id}/rider/pdf", cfg.GetShowRiderPDF)
+	authed.HandleFunc("GET /shows/{show_id}/input-list", cfg.GetShowInputList)
+	authed.HandleFunc("GET /shows/{show_id}/console/{console}", cfg.GetShowConsoleScene)
+	authed.HandleFunc("GET /shows/{show_id}/patch", cfg.GetShowPatch)
+	authed.HandleFunc("GET /shows/{show_id}/compatibility", cfg.GetShowCompatibility)
+	authed.HandleFunc("GET /shows/{show_id}/schedule", cfg.GetShowSchedule)
+	authed.HandleFunc("POST /shows/{show_id}/schedule", cfg.CreateScheduleItem)
+	authed.HandleFunc("PUT /shows/{show_id}/schedule/{item_id}", cfg.UpdateScheduleItem)
+	authed.HandleFunc("DELETE /shows/{show_id}/schedule/{item_id}", cfg.DeleteScheduleItem)
+	authed.HandleFunc("GET /shows/{show_id}/day-sheet", cfg.GetDaySheet)
+	authed.HandleFunc("PUT /shows/{show_id}/day-sheet", cfg.UpdateDaySheetNotes)
+	authed.HandleFunc("GET /shows/{show_id}/venue-supply", cfg.GetShowVenueSupply)
+	authed.HandleFunc("GET /shows/{show_id}/power", cfg.GetShowPower)
+	authed.HandleFunc("POST /shows/{show_id}/share-links", cfg.CreateShowShareLink)
+	authed.HandleFunc("POST /shows/{show_id}/wireless/plan", cfg.PlanShowWireless)
+	authed.HandleFunc("GET /shows/{show_id}/packing-list", cfg.GetShowPackingList)
+	authed.HandleFunc("POST /shows/{show_id}/packing-list/checks", cfg.CheckPackingItem)
+	authed.HandleFunc("GET /shows/{show_id}/packing-list/missing", cfg.GetShowPackingMissing)
+	authed.HandleFunc("GET /shows/{show_id}/manifest", cfg.GetShowManifest)
+	authed.HandleFunc("GET /shows/{show_id}/carnet", cfg.GetShowCarnet)
+	authed.HandleFunc("POST /wireless/check", cfg.CheckWireless)
+	authed.HandleFunc("GET /consoles", cfg.GetConsoles)
+	authed.HandleFunc("GET /catalog", cfg.SearchCatalog)
+	authed.HandleFunc("GET /catalog/{item_id}", cfg.GetCatalogItem)
+
+	// share links are read by venues who don't have an account with us
+	router.HandleFunc("GET /share/{token}", cfg.ViewSharedRider)
+	router.HandleFunc("GET /share/{token}/pdf", cfg.GetSharedRiderPDF)
+	router.HandleFunc("POST /share/{token}/acknowledge", cfg.AcknowledgeSharedRider)
+	router.HandleFunc("POST /share/{token}/flag", cfg.FlagSharedRider)
+
+	// calendar apps subscribe without logging in, so the feed URL carries a secret token
+	router.HandleFunc("GET /calendar/{token}", cfg.GetCalendar)
+	router.HandleFunc("GET /calendar/{token}/bands/{band}", cfg.GetBandCalendar)
 
 	if os.Getenv("ENVIRONMENT") == "development" {
 		dev := http.NewServeMux()
@@ -0,0 +1,302 @@
+// Package pdf writes simple PDF documents: headings, paragraphs, tables and
+// box diagrams laid out top to bottom on US letter pages using the standard
+// Helvetica fonts, so no font files need to be embedded.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	pageWidth  = 612.0
+	pageHeight = 792.0
+	margin     = 50.0
+
+	fontRegular = "F1"
+	fontBold    = "F2"
+)
+
+type Document struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func New() *Document {
+	d := &Document{}
+	d.newPage()
+	return d
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+func (d *Document) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// reserve moves the cursor down by height, starting a new page first if the
+// space isn't available on the current one.
+func (d *Document) reserve(height float64) {
+	if d.y-height < margin {
+		d.newPage()
+	}
+	d.y -= height
+}
+
+func (d *Document) text(font string, size, x float64, s string) {
+	fmt.Fprintf(d.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, d.y, escape(s))
+}
+
+func (d *Document) Title(s string) {
+	d.reserve(24)
+	d.text(fontBold, 18, margin, s)
+	d.y -= 8
+}
+
+func (d *Document) Heading(s string) {
+	d.y -= 6
+	d.reserve(16)
+	d.text(fontBold, 13, margin, s)
+	d.y -= 6
+}
+
+func (d *Document) Paragraph(s string) {
+	for _, line := range strings.Split(s, "\n") {
+		for _, wrapped := range wrap(line, 10, pageWidth-2*margin) {
+			d.reserve(14)
+			d.text(fontRegular, 10, margin, wrapped)
+		}
+	}
+	d.y -= 4
+}
+
+// Table lays out rows in equal width columns. Cells that don't fit in their
+// column are truncated rather than wrapped.
+func (d *Document) Table(headers []string, rows [][]string) {
+	columns := len(headers)
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	if columns == 0 {
+		return
+	}
+	width := (pageWidth - 2*margin) / float64(columns)
+
+	line := func(font string, cells []string) {
+		d.reserve(13)
+		for i, cell := range cells {
+			d.text(font, 9, margin+float64(i)*width, truncate(cell, 9, width-4))
+		}
+	}
+	if len(headers) > 0 {
+		line(fontBold, headers)
+		fmt.Fprintf(d.page(), "%.2f %.2f m %.2f %.2f l S\n", margin, d.y-3, pageWidth-margin, d.y-3)
+		d.y -= 3
+	}
+	for _, row := range rows {
+		line(fontRegular, row)
+	}
+	d.y -= 6
+}
+
+// Box is a labelled rectangle on a diagram. Coordinates are in the
+// diagram's own units with the origin at the top left.
+type Box struct {
+	X, Y, Width, Height float64
+	Label               string
+	// Shapes are drawn instead of the box's outline when there are any.
+	Shapes []Shape
+}
+
+// Marker is a labelled point on a diagram.
+type Marker struct {
+	X, Y  float64
+	Label string
+	// Shapes are drawn instead of the usual diamond when there are any.
+	Shapes []Shape
+}
+
+// Shape is a path drawn on a diagram, in diagram units.
+type Shape struct {
+	Segments []Segment
+	Fill     bool
+	Stroke   bool
+}
+
+// Segment is a move (M), line (L) or cubic curve (C) to its points, or a
+// close (Z).
+type Segment struct {
+	Op     byte
+	Points []float64
+}
+
+// Diagram draws an outline of width by height diagram units scaled to the
+// width of the page, with boxes and markers inside it.
+func (d *Document) Diagram(width, height float64, boxes []Box, markers []Marker) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+	scale := (pageWidth - 2*margin) / width
+	if height*scale > pageHeight/2 {
+		scale = pageHeight / 2 / height
+	}
+	d.reserve(height*scale + 4)
+	top := d.y + height*scale
+	px := func(x float64) float64 { return margin + x*scale }
+	py := func(y float64) float64 { return top - y*scale }
+
+	page := d.page()
+	fmt.Fprintf(page, "0.5 w %.2f %.2f %.2f %.2f re S\n", px(0), py(height), width*scale, height*scale)
+	// shapes are filled light grey and stroked black, leaving the fill
+	// color black again for labels
+	drawShapes := func(shapes []Shape) {
+		page.WriteString("0.8 g\n")
+		for _, shape := range shapes {
+			for _, seg := range shape.Segments {
+				for i := 0; i+1 < len(seg.Points); i += 2 {
+					fmt.Fprintf(page, "%.2f %.2f ", px(seg.Points[i]), py(seg.Points[i+1]))
+				}
+				switch seg.Op {
+				case 'M':
+					page.WriteString("m\n")
+				case 'L':
+					page.WriteString("l\n")
+				case 'C':
+					page.WriteString("c\n")
+				case 'Z':
+					page.WriteString("h\n")
+				}
+			}
+			switch {
+			case shape.Fill && shape.Stroke:
+				page.WriteString("B\n")
+			case shape.Fill:
+				page.WriteString("f\n")
+			default:
+				page.WriteString("S\n")
+			}
+		}
+		page.WriteString("0 g\n")
+	}
+
+	for _, box := range boxes {
+		if len(box.Shapes) > 0 {
+			drawShapes(box.Shapes)
+		} else {
+			fmt.Fprintf(page, "%.2f %.2f %.2f %.2f re S\n", px(box.X), py(box.Y+box.Height), box.Width*scale, box.Height*scale)
+		}
+		fmt.Fprintf(page, "BT /%s 7.0 Tf %.2f %.2f Td (%s) Tj ET\n", fontRegular, px(box.X)+2, py(box.Y)-8, escape(truncate(box.Label, 7, box.Width*scale-4)))
+	}
+	for _, marker := range markers {
+		x, y := px(marker.X), py(marker.Y)
+		if len(marker.Shapes) > 0 {
+			drawShapes(marker.Shapes)
+		} else {
+			fmt.Fprintf(page, "%.2f %.2f m %.2f %.2f l %.2f %.2f l %.2f %.2f l h f\n", x, y+4, x+4, y, x, y-4, x-4, y)
+		}
+		fmt.Fprintf(page, "BT /%s 7.0 Tf %.2f %.2f Td (%s) Tj ET\n", fontBold, x+6, y-2, escape(marker.Label))
+	}
+	d.y -= 10
+}
+
+// Write serializes the document, building the cross reference table from
+// the offsets of each object as it goes.
+func (d *Document) Write(w io.Writer) error {
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range d.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fontRegular, fontBold, 6+2*i,
+		))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// escape converts s to the single byte WinAnsi encoding used by the standard
+// fonts and escapes the characters that are special inside a PDF string.
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		case r == '\t':
+			b.WriteByte(' ')
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// textWidth approximates the rendered width of s. Helvetica glyphs average
+// a little over half the font size, which is close enough for layout.
+func textWidth(s string, size float64) float64 {
+	return float64(len([]rune(s))) * size * 0.55
+}
+
+func wrap(s string, size, width float64) []string {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return []string{""}
+	}
+	var lines []string
+	current := words[0]
+	for _, word := range words[1:] {
+		if textWidth(current+" "+word, size) > width {
+			lines = append(lines, current)
+			current = word
+			continue
+		}
+		current += " " + word
+	}
+	return append(lines, current)
+}
+
+func truncate(s string, size, width float64) string {
+	runes := []rune(s)
+	if textWidth(s, size) <= width {
+		return s
+	}
+	for len(runes) > 0 && textWidth(string(runes)+"...", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
@@ -0,0 +1,262 @@
+// Package rf coordinates wireless frequencies so that no transmitter sits on
+// or near a third-order intermodulation product of the others. Frequencies
+// are whole kHz so that grid steps and spacings compare exactly.
+package rf
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Range is an inclusive band of frequencies in kHz.
+type Range struct {
+	Low  int `json:"low"`
+	High int `json:"high"`
+}
+
+func (r Range) Contains(f int) bool {
+	return f >= r.Low && f <= r.High
+}
+
+type Transmitter struct {
+	Name   string
+	Tuning Range
+	// Frequency is zero when the transmitter still needs one.
+	Frequency int
+}
+
+type Settings struct {
+	// Step is the tuning grid every frequency is picked from.
+	Step int
+	// CarrierSpacing is the minimum distance between two transmitters.
+	CarrierSpacing int
+	// IMSpacing is the minimum distance between a transmitter and any
+	// intermodulation product it isn't part of.
+	IMSpacing  int
+	Exclusions []Range
+}
+
+func (s Settings) withDefaults() Settings {
+	if s.Step <= 0 {
+		s.Step = 25
+	}
+	if s.CarrierSpacing <= 0 {
+		s.CarrierSpacing = 350
+	}
+	if s.IMSpacing <= 0 {
+		s.IMSpacing = 100
+	}
+	return s
+}
+
+func (s Settings) excluded(f int) bool {
+	return slices.ContainsFunc(s.Exclusions, func(r Range) bool {
+		return r.Contains(f)
+	})
+}
+
+type ConflictKind string
+
+const (
+	ConflictOutOfRange ConflictKind = "out_of_range"
+	ConflictExcluded   ConflictKind = "excluded"
+	ConflictSpacing    ConflictKind = "carrier_spacing"
+	// ConflictIM2 is a hit from a 2f1-f2 product of two other transmitters.
+	ConflictIM2 ConflictKind = "im_two_transmitter"
+	// ConflictIM3 is a hit from a f1+f2-f3 product of three other
+	// transmitters.
+	ConflictIM3 ConflictKind = "im_three_transmitter"
+)
+
+type Conflict struct {
+	Kind        ConflictKind `json:"kind"`
+	Transmitter string       `json:"transmitter"`
+	Frequency   int          `json:"frequency_khz"`
+	// Product and Sources describe the offending carrier or
+	// intermodulation product.
+	Product int      `json:"product_khz,omitempty"`
+	Sources []string `json:"sources,omitempty"`
+}
+
+type product struct {
+	frequency int
+	kind      ConflictKind
+	sources   []int
+}
+
+// products lists every third-order product of the carriers, remembering
+// which carriers produced each one.
+func products(carriers []int) []product {
+	var ps []product
+	for i, a := range carriers {
+		for j, b := range carriers {
+			if i == j {
+				continue
+			}
+			ps = append(ps, product{2*a - b, ConflictIM2, []int{i, j}})
+			for k, c := range carriers {
+				// a+b is symmetric so only take each pair once
+				if k == i || k == j || j < i {
+					continue
+				}
+				ps = append(ps, product{a + b - c, ConflictIM3, []int{i, j, k}})
+			}
+		}
+	}
+	return ps
+}
+
+// Check reports every problem with the transmitters that already have a
+// frequency. Transmitters without one are ignored.
+func Check(txs []Transmitter, s Settings) []Conflict {
+	s = s.withDefaults()
+	var assigned []Transmitter
+	for _, tx := range txs {
+		if tx.Frequency != 0 {
+			assigned = append(assigned, tx)
+		}
+	}
+
+	carriers := make([]int, len(assigned))
+	for i, tx := range assigned {
+		carriers[i] = tx.Frequency
+	}
+
+	conflicts := []Conflict{}
+	for i, tx := range assigned {
+		if !tx.Tuning.Contains(tx.Frequency) {
+			conflicts = append(conflicts, Conflict{Kind: ConflictOutOfRange, Transmitter: tx.Name, Frequency: tx.Frequency})
+		}
+		if s.excluded(tx.Frequency) {
+			conflicts = append(conflicts, Conflict{Kind: ConflictExcluded, Transmitter: tx.Name, Frequency: tx.Frequency})
+		}
+		for j, other := range assigned {
+			if i != j && abs(tx.Frequency-other.Frequency) < s.CarrierSpacing {
+				conflicts = append(conflicts, Conflict{
+					Kind:        ConflictSpacing,
+					Transmitter: tx.Name,
+					Frequency:   tx.Frequency,
+					Product:     other.Frequency,
+					Sources:     []string{other.Name},
+				})
+			}
+		}
+	}
+
+	for _, p := range products(carriers) {
+		for i, tx := range assigned {
+			if slices.Contains(p.sources, i) || abs(tx.Frequency-p.frequency) >= s.IMSpacing {
+				continue
+			}
+			sources := make([]string, len(p.sources))
+			for n, source := range p.sources {
+				sources[n] = assigned[source].Name
+			}
+			conflicts = append(conflicts, Conflict{
+				Kind:        p.kind,
+				Transmitter: tx.Name,
+				Frequency:   tx.Frequency,
+				Product:     p.frequency,
+				Sources:     sources,
+			})
+		}
+	}
+	return conflicts
+}
+
+// Plan gives a frequency to every transmitter that doesn't have one, leaving
+// existing frequencies alone. Transmitters are placed greedily, narrowest
+// tuning range first, each on the lowest grid frequency compatible with
+// everything placed so far. The names of any that can't be placed are
+// returned alongside the plan.
+func Plan(txs []Transmitter, s Settings) ([]Transmitter, []string) {
+	s = s.withDefaults()
+	planned := slices.Clone(txs)
+
+	var carriers []int
+	var pending []int
+	for i, tx := range planned {
+		if tx.Frequency != 0 {
+			carriers = append(carriers, tx.Frequency)
+		} else {
+			pending = append(pending, i)
+		}
+	}
+	slices.SortStableFunc(pending, func(a, b int) int {
+		return cmp.Compare(planned[a].Tuning.High-planned[a].Tuning.Low, planned[b].Tuning.High-planned[b].Tuning.Low)
+	})
+
+	unplaced := []string{}
+	for _, i := range pending {
+		f, ok := s.pick(planned[i].Tuning, carriers)
+		if !ok {
+			unplaced = append(unplaced, planned[i].Name)
+			continue
+		}
+		planned[i].Frequency = f
+		carriers = append(carriers, f)
+	}
+	return planned, unplaced
+}
+
+func (s Settings) pick(tuning Range, carriers []int) (int, bool) {
+	var existing []int
+	for _, p := range products(carriers) {
+		existing = append(existing, p.frequency)
+	}
+	slices.Sort(existing)
+
+	start := tuning.Low
+	if rem := start % s.Step; rem != 0 {
+		start += s.Step - rem
+	}
+	for f := start; f <= tuning.High; f += s.Step {
+		if !s.excluded(f) && s.compatible(f, carriers, existing) {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// compatible checks a candidate against the carriers already placed, the
+// products they already make, and the new products it would make with them.
+func (s Settings) compatible(f int, carriers, existing []int) bool {
+	for _, c := range carriers {
+		if abs(f-c) < s.CarrierSpacing {
+			return false
+		}
+	}
+	if near(existing, f, s.IMSpacing) {
+		return false
+	}
+
+	all := append(slices.Clone(carriers), f)
+	slices.Sort(all)
+	for i, a := range carriers {
+		if near(all, 2*f-a, s.IMSpacing) || near(all, 2*a-f, s.IMSpacing) {
+			return false
+		}
+		for j, b := range carriers {
+			if i == j {
+				continue
+			}
+			if near(all, f+a-b, s.IMSpacing) || (j > i && near(all, a+b-f, s.IMSpacing)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// near reports whether any of the sorted frequencies is within spacing of f.
+func near(sorted []int, f, spacing int) bool {
+	i, _ := slices.BinarySearch(sorted, f-spacing+1)
+	return i < len(sorted) && sorted[i] < f+spacing
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
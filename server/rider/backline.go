@@ -0,0 +1,94 @@
+package rider
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrProvidedByInvalid   = errors.New(`provided by must be "band", "venue" or "rental"`)
+	ErrBacklineNameMissing = errors.New("backline items need a name or an item from the gear inventory or catalog")
+	ErrQuantityInvalid     = errors.New("quantities can't be negative")
+)
+
+// ProvidedBy records who is responsible for getting a piece of gear to the
+// stage.
+type ProvidedBy string
+
+const (
+	ProvidedByBand   ProvidedBy = "band"
+	ProvidedByVenue  ProvidedBy = "venue"
+	ProvidedByRental ProvidedBy = "rental"
+)
+
+func (p ProvidedBy) validate() error {
+	switch p {
+	case ProvidedByBand, ProvidedByVenue, ProvidedByRental:
+		return nil
+	}
+	return fmt.Errorf("%w: got %q", ErrProvidedByInvalid, p)
+}
+
+func (p ProvidedBy) String() string {
+	switch p {
+	case ProvidedByBand:
+		return "band brings"
+	case ProvidedByVenue:
+		return "venue provides"
+	case ProvidedByRental:
+		return "rental"
+	}
+	return string(p)
+}
+
+type BacklineItem struct {
+	// ID is filled in when the rider is saved, see WithLineIDs.
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	// Quantity defaults to one when left out.
+	Quantity    int        `json:"quantity"`
+	Specs       string     `json:"specs"`
+	Substitutes []string   `json:"substitutes"`
+	ProvidedBy  ProvidedBy `json:"provided_by"`
+	Notes       string     `json:"notes"`
+	// GearID refers to an item in the gear inventory, which fills in the
+	// name and specs when they're left out.
+	GearID int32 `json:"gear_id,omitempty"`
+	// CatalogID refers to the equipment catalog, which fills in the name,
+	// category and substitutes when they're left out.
+	CatalogID string `json:"catalog_id,omitempty"`
+}
+
+func (b BacklineItem) count() int {
+	return max(b.Quantity, 1)
+}
+
+func (b BacklineItem) validate() error {
+	if strings.TrimSpace(b.Name) == "" && b.GearID == 0 && b.CatalogID == "" {
+		return ErrBacklineNameMissing
+	} else if b.Quantity < 0 {
+		return fmt.Errorf("%w: %s", ErrQuantityInvalid, b.Name)
+	}
+	return b.ProvidedBy.validate()
+}
+
+func (d Document) backlineSection() Section {
+	section := Section{
+		Title:   "Backline",
+		Headers: []string{"Item", "Qty", "Specs", "Acceptable substitutes", "Provided by"},
+	}
+	for i, item := range d.Backline {
+		section.Rows = append(section.Rows, []string{
+			item.Name,
+			strconv.Itoa(item.count()),
+			item.Specs,
+			strings.Join(item.Substitutes, ", "),
+			item.ProvidedBy.String(),
+		})
+		section.RowKeys = append(section.RowKeys, fmt.Sprintf("backline:%d", i+1))
+	}
+	return section
+}
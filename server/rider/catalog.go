@@ -0,0 +1,75 @@
+package rider
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jkellogg01/rider/server/catalog"
+)
+
+var (
+	ErrCatalogItemUnknown  = errors.New("no matching catalog item")
+	ErrCatalogItemNotInput = errors.New("input list channels can only refer to mics and DIs from the catalog")
+)
+
+func (d Document) validateCatalog() error {
+	for _, ch := range d.InputList {
+		if ch.CatalogID == "" {
+			continue
+		}
+		item, ok := catalog.Lookup(ch.CatalogID)
+		if !ok {
+			return fmt.Errorf("%w: channel %d refers to %q", ErrCatalogItemUnknown, ch.Number, ch.CatalogID)
+		} else if item.Category != catalog.CategoryMic && item.Category != catalog.CategoryDI {
+			return fmt.Errorf("%w: channel %d refers to %s", ErrCatalogItemNotInput, ch.Number, item.Name())
+		}
+	}
+	for _, item := range d.Backline {
+		if item.CatalogID == "" {
+			continue
+		} else if _, ok := catalog.Lookup(item.CatalogID); !ok {
+			return fmt.Errorf("%w: backline refers to %q", ErrCatalogItemUnknown, item.CatalogID)
+		}
+	}
+	return nil
+}
+
+// WithCatalog fills in the blanks on lines that refer to catalog items, and
+// turns on phantom power for channels whose mic or DI needs it. Anything
+// written into the rider itself wins over the catalog.
+func (d Document) WithCatalog() Document {
+	d.InputList = slices.Clone(d.InputList)
+	for i, ch := range d.InputList {
+		item, ok := catalog.Lookup(ch.CatalogID)
+		if !ok {
+			continue
+		}
+		if ch.Mic == "" {
+			d.InputList[i].Mic = item.Name()
+		}
+		d.InputList[i].Phantom = ch.Phantom || item.Phantom
+	}
+
+	d.Backline = slices.Clone(d.Backline)
+	for i, line := range d.Backline {
+		item, ok := catalog.Lookup(line.CatalogID)
+		if !ok {
+			continue
+		}
+		if line.Name == "" {
+			d.Backline[i].Name = item.Name()
+		}
+		if line.Category == "" {
+			d.Backline[i].Category = string(item.Category)
+		}
+		if len(line.Substitutes) == 0 {
+			for _, id := range item.Substitutes {
+				if sub, ok := catalog.Lookup(id); ok {
+					d.Backline[i].Substitutes = append(d.Backline[i].Substitutes, sub.Name())
+				}
+			}
+		}
+	}
+	return d
+}
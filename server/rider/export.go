@@ -0,0 +1,127 @@
+package rider
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jkellogg01/rider/server/console"
+)
+
+// Table is a titled table for exporting to other tools.
+type Table struct {
+	Title   string
+	Headers []string
+	Rows    [][]string
+}
+
+// InputListTables is the input list followed by the monitor matrix, if the
+// rider has monitor mixes.
+func (d Document) InputListTables() []Table {
+	input := d.inputListSection()
+	tables := []Table{{Title: input.Title, Headers: input.Headers, Rows: input.Rows}}
+
+	headers, rows := d.MonitorMatrix()
+	if len(rows) > 0 {
+		tables = append(tables, Table{Title: "Monitor matrix", Headers: headers, Rows: rows})
+	}
+	return tables
+}
+
+// WriteMarkdown writes the tables as GitHub flavoured Markdown.
+func WriteMarkdown(w io.Writer, tables []Table) error {
+	escape := strings.NewReplacer("|", `\|`, "\n", " ")
+	line := func(cells []string) string {
+		escaped := make([]string, len(cells))
+		for i, cell := range cells {
+			escaped[i] = escape.Replace(cell)
+		}
+		return "| " + strings.Join(escaped, " | ") + " |\n"
+	}
+
+	var b strings.Builder
+	for i, table := range tables {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n\n", table.Title)
+		b.WriteString(line(table.Headers))
+		divider := make([]string, len(table.Headers))
+		for j := range divider {
+			divider[j] = "---"
+		}
+		b.WriteString(line(divider))
+		for _, row := range table.Rows {
+			b.WriteString(line(padRow(row, len(table.Headers))))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteText writes the tables as space aligned plain text, which survives
+// being pasted into an email in a fixed width font.
+func WriteText(w io.Writer, tables []Table) error {
+	var b strings.Builder
+	for i, table := range tables {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(table.Title + "\n")
+		b.WriteString(strings.Repeat("=", utf8.RuneCountInString(table.Title)) + "\n\n")
+
+		widths := make([]int, len(table.Headers))
+		for j, header := range table.Headers {
+			widths[j] = utf8.RuneCountInString(header)
+		}
+		for _, row := range table.Rows {
+			for j, cell := range padRow(row, len(widths)) {
+				widths[j] = max(widths[j], utf8.RuneCountInString(cell))
+			}
+		}
+
+		line := func(cells []string) {
+			var parts []string
+			for j, cell := range cells {
+				parts = append(parts, cell+strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell)))
+			}
+			b.WriteString(strings.TrimRight(strings.Join(parts, "  "), " ") + "\n")
+		}
+		line(table.Headers)
+		rules := make([]string, len(widths))
+		for j, width := range widths {
+			rules[j] = strings.Repeat("-", width)
+		}
+		line(rules)
+		for _, row := range table.Rows {
+			line(padRow(row, len(widths)))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// padRow fits a row to the table's columns, dropping newlines so a cell
+// can't break the layout.
+func padRow(row []string, columns int) []string {
+	padded := make([]string, columns)
+	for i := 0; i < columns && i < len(row); i++ {
+		padded[i] = strings.ReplaceAll(row[i], "\n", " ")
+	}
+	return padded
+}
+
+// ConsoleScene is the input list as a mixing desk sees it.
+func (d Document) ConsoleScene(name string) console.Scene {
+	scene := console.Scene{Name: name, Channels: make([]console.Channel, 0, len(d.InputList))}
+	for _, ch := range d.InputList {
+		scene.Channels = append(scene.Channels, console.Channel{
+			Number:  ch.Number,
+			Name:    ch.Source,
+			Color:   ch.Color,
+			Phantom: ch.Phantom,
+		})
+	}
+	return scene
+}
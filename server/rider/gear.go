@@ -0,0 +1,94 @@
+package rider
+
+import (
+	"slices"
+	"strings"
+)
+
+// Gear is the part of an inventory item that a rider borrows when one of its
+// lines refers to the item instead of describing it in free text.
+type Gear struct {
+	Name  string
+	Make  string
+	Model string
+	Watts int
+}
+
+func (g Gear) label() string {
+	if label := strings.TrimSpace(g.Make + " " + g.Model); label != "" {
+		return label
+	}
+	return g.Name
+}
+
+// GearIDs lists the inventory items the document refers to.
+func (d Document) GearIDs() []int32 {
+	var ids []int32
+	for _, ch := range d.InputList {
+		ids = append(ids, ch.GearID)
+	}
+	for _, item := range d.Backline {
+		ids = append(ids, item.GearID)
+	}
+	if d.StagePlot != nil {
+		for _, item := range d.StagePlot.Items {
+			ids = append(ids, item.GearID)
+		}
+	}
+	ids = slices.DeleteFunc(ids, func(id int32) bool {
+		return id == 0
+	})
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// WithGear fills in the blanks on lines that refer to inventory items, so
+// the inventory only has to be kept up to date in one place. Anything written
+// into the rider itself wins over the inventory.
+func (d Document) WithGear(gear map[int32]Gear) Document {
+	d.InputList = slices.Clone(d.InputList)
+	for i, ch := range d.InputList {
+		g, ok := gear[ch.GearID]
+		if ok && ch.Mic == "" {
+			d.InputList[i].Mic = g.label()
+		}
+	}
+
+	d.Backline = slices.Clone(d.Backline)
+	for i, item := range d.Backline {
+		g, ok := gear[item.GearID]
+		if !ok {
+			continue
+		}
+		if item.Name == "" {
+			d.Backline[i].Name = g.Name
+		}
+		if item.Specs == "" {
+			d.Backline[i].Specs = strings.TrimSpace(g.Make + " " + g.Model)
+		}
+	}
+
+	if d.StagePlot != nil {
+		plot := *d.StagePlot
+		plot.Items = slices.Clone(plot.Items)
+		for i, item := range plot.Items {
+			g, ok := gear[item.GearID]
+			if !ok {
+				continue
+			}
+			if item.Label == "" {
+				plot.Items[i].Label = g.Name
+			}
+			if g.Watts > 0 && (item.Power == nil || item.Power.Watts == 0) {
+				power := PowerNeed{}
+				if item.Power != nil {
+					power = *item.Power
+				}
+				power.Watts = g.Watts
+				plot.Items[i].Power = &power
+			}
+		}
+		d.StagePlot = &plot
+	}
+	return d
+}
@@ -0,0 +1,112 @@
+package rider
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrHospitalityCountInvalid = errors.New("hospitality counts can't be negative")
+
+type Hospitality struct {
+	DressingRooms []DressingRoom `json:"dressing_rooms"`
+	Catering      string         `json:"catering"`
+	Dietary       []DietaryNeed  `json:"dietary"`
+	// UseMemberDietary adds the dietary restrictions from each band member's
+	// profile to Dietary whenever the rider is read.
+	UseMemberDietary bool `json:"use_member_dietary"`
+	// MemberDietary is what members' profiles said when the revision was
+	// published, which is what a published rider shows from then on.
+	MemberDietary []DietaryNeed `json:"member_dietary,omitempty"`
+	Drinks        []string      `json:"drinks"`
+	Towels        int           `json:"towels"`
+	GuestList     int           `json:"guest_list"`
+	Notes         string        `json:"notes"`
+}
+
+type DressingRoom struct {
+	Name         string `json:"name"`
+	Capacity     int    `json:"capacity"`
+	Requirements string `json:"requirements"`
+}
+
+type DietaryNeed struct {
+	Member       string `json:"member"`
+	Restrictions string `json:"restrictions"`
+}
+
+func (h *Hospitality) validate() error {
+	if h.Towels < 0 || h.GuestList < 0 {
+		return ErrHospitalityCountInvalid
+	}
+	for _, room := range h.DressingRooms {
+		if room.Capacity < 0 {
+			return ErrHospitalityCountInvalid
+		}
+	}
+	return nil
+}
+
+// WithMemberDietary fills in dietary needs from member profiles if the rider
+// asks for it. Needs written into the rider itself win over a profile for the
+// same member.
+func (d Document) WithMemberDietary(members []DietaryNeed) Document {
+	if d.Hospitality == nil || !d.Hospitality.UseMemberDietary {
+		return d
+	}
+
+	hospitality := *d.Hospitality
+	hospitality.Dietary = append([]DietaryNeed(nil), hospitality.Dietary...)
+	for _, member := range members {
+		listed := false
+		for _, need := range hospitality.Dietary {
+			listed = listed || strings.EqualFold(need.Member, member.Member)
+		}
+		if !listed {
+			hospitality.Dietary = append(hospitality.Dietary, member)
+		}
+	}
+	d.Hospitality = &hospitality
+	return d
+}
+
+func (h *Hospitality) sections() []Section {
+	general := Section{Title: "Hospitality"}
+	if h.Catering != "" {
+		general.Lines = append(general.Lines, fmt.Sprintf("Catering: %s", h.Catering))
+	}
+	if len(h.Drinks) > 0 {
+		general.Lines = append(general.Lines, fmt.Sprintf("Drinks: %s", strings.Join(h.Drinks, ", ")))
+	}
+	if h.Towels > 0 {
+		general.Lines = append(general.Lines, fmt.Sprintf("Towels: %d", h.Towels))
+	}
+	if h.GuestList > 0 {
+		general.Lines = append(general.Lines, fmt.Sprintf("Guest list: %d", h.GuestList))
+	}
+	if h.Notes != "" {
+		general.Lines = append(general.Lines, strings.Split(h.Notes, "\n")...)
+	}
+	if len(h.DressingRooms) > 0 {
+		general.Headers = []string{"Dressing room", "Capacity", "Requirements"}
+		for i, room := range h.DressingRooms {
+			general.Rows = append(general.Rows, []string{room.Name, strconv.Itoa(room.Capacity), room.Requirements})
+			general.RowKeys = append(general.RowKeys, fmt.Sprintf("hospitality:dressing-room:%d", i+1))
+		}
+	}
+
+	sections := []Section{general}
+	if len(h.Dietary) > 0 {
+		dietary := Section{
+			Title:   "Dietary requirements",
+			Headers: []string{"Member", "Restrictions"},
+		}
+		for i, need := range h.Dietary {
+			dietary.Rows = append(dietary.Rows, []string{need.Member, need.Restrictions})
+			dietary.RowKeys = append(dietary.RowKeys, fmt.Sprintf("hospitality:dietary:%d", i+1))
+		}
+		sections = append(sections, dietary)
+	}
+	return sections
+}
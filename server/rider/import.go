@@ -0,0 +1,202 @@
+package rider
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// InputColumn is a field of a channel that an imported column can fill.
+type InputColumn string
+
+const (
+	ColumnChannel   InputColumn = "channel"
+	ColumnSource    InputColumn = "source"
+	ColumnMic       InputColumn = "mic"
+	ColumnStand     InputColumn = "stand"
+	ColumnPhantom   InputColumn = "phantom"
+	ColumnPerformer InputColumn = "performer"
+	ColumnNotes     InputColumn = "notes"
+)
+
+// headerNames are the column titles we've seen on bands' own input lists,
+// normalized by normalizeHeader.
+var headerNames = map[string]InputColumn{
+	"ch":          ColumnChannel,
+	"chan":        ColumnChannel,
+	"channel":     ColumnChannel,
+	"input":       ColumnChannel,
+	"no":          ColumnChannel,
+	"#":           ColumnChannel,
+	"source":      ColumnSource,
+	"instrument":  ColumnSource,
+	"inst":        ColumnSource,
+	"description": ColumnSource,
+	"name":        ColumnSource,
+	"mic":         ColumnMic,
+	"mic/di":      ColumnMic,
+	"mic/dibox":   ColumnMic,
+	"micdi":       ColumnMic,
+	"microphone":  ColumnMic,
+	"di":          ColumnMic,
+	"stand":       ColumnStand,
+	"stands":      ColumnStand,
+	"48v":         ColumnPhantom,
+	"+48v":        ColumnPhantom,
+	"+48":         ColumnPhantom,
+	"p48":         ColumnPhantom,
+	"phantom":     ColumnPhantom,
+	"performer":   ColumnPerformer,
+	"player":      ColumnPerformer,
+	"musician":    ColumnPerformer,
+	"who":         ColumnPerformer,
+	"notes":       ColumnNotes,
+	"note":        ColumnNotes,
+	"comments":    ColumnNotes,
+	"comment":     ColumnNotes,
+	"remarks":     ColumnNotes,
+}
+
+// positionalLayout is assumed when a sheet has no header row we recognize.
+var positionalLayout = []InputColumn{ColumnChannel, ColumnSource, ColumnMic, ColumnStand, ColumnNotes}
+
+// headerSearchRows is how far down a sheet we look for the header row, past
+// any band name or date printed above it.
+const headerSearchRows = 10
+
+var channelNumber = regexp.MustCompile(`\d+`)
+
+func normalizeHeader(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.TrimSuffix(s, ".")
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(s)
+}
+
+// ImportError is a problem with one row of an imported input list. Rows are
+// numbered from one, the way a spreadsheet shows them.
+type ImportError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+type InputListImport struct {
+	// Layout maps each recognized column, by position, to the channel field
+	// it fills.
+	Layout    map[int]InputColumn `json:"layout"`
+	HeaderRow int                 `json:"header_row,omitempty"`
+	Channels  []Channel           `json:"channels"`
+	Errors    []ImportError       `json:"errors"`
+}
+
+// ImportInputList reads channels from the rows of a CSV file or spreadsheet.
+// The column layout is detected from the header row, and every row with a
+// problem is reported rather than stopping at the first one.
+func ImportInputList(rows [][]string) InputListImport {
+	result := InputListImport{Channels: []Channel{}, Errors: []ImportError{}}
+	start := 0
+	result.Layout, result.HeaderRow = detectLayout(rows)
+	if result.HeaderRow > 0 {
+		start = result.HeaderRow
+	}
+
+	seen := map[int]int{}
+	for i := start; i < len(rows); i++ {
+		row := rows[i]
+		if blankRow(row) {
+			continue
+		}
+
+		var ch Channel
+		var problems []string
+		for column, field := range result.Layout {
+			if column >= len(row) {
+				continue
+			}
+			value := strings.TrimSpace(row[column])
+			switch field {
+			case ColumnChannel:
+				number := channelNumber.FindString(value)
+				if number == "" {
+					problems = append(problems, "missing a channel number")
+					continue
+				}
+				ch.Number, _ = strconv.Atoi(number)
+			case ColumnSource:
+				ch.Source = value
+			case ColumnMic:
+				ch.Mic = value
+			case ColumnStand:
+				ch.Stand = value
+			case ColumnPhantom:
+				ch.Phantom = truthy(value)
+			case ColumnPerformer:
+				ch.Performer = value
+			case ColumnNotes:
+				ch.Notes = value
+			}
+		}
+		if ch.Number == 0 && len(problems) == 0 {
+			problems = append(problems, "missing a channel number")
+		}
+		if ch.Source == "" {
+			problems = append(problems, "missing a source")
+		}
+		if first, ok := seen[ch.Number]; ok && ch.Number != 0 {
+			problems = append(problems, "channel "+strconv.Itoa(ch.Number)+" is already on row "+strconv.Itoa(first))
+		}
+
+		if len(problems) > 0 {
+			result.Errors = append(result.Errors, ImportError{Row: i + 1, Message: strings.Join(problems, ", ")})
+			continue
+		}
+		seen[ch.Number] = i + 1
+		result.Channels = append(result.Channels, ch)
+	}
+	return result
+}
+
+// detectLayout finds the header row and maps its columns, falling back to the
+// most common layout when there's no header. The returned row is one based,
+// or zero when no header was found.
+func detectLayout(rows [][]string) (map[int]InputColumn, int) {
+	for i := 0; i < len(rows) && i < headerSearchRows; i++ {
+		layout := map[int]InputColumn{}
+		used := map[InputColumn]bool{}
+		for column, cell := range rows[i] {
+			field, ok := headerNames[normalizeHeader(cell)]
+			if !ok || used[field] {
+				continue
+			}
+			layout[column] = field
+			used[field] = true
+		}
+		// a channel and a source are the least a header row needs to be
+		// told apart from a channel that happens to say "Vocal"
+		if used[ColumnChannel] && used[ColumnSource] {
+			return layout, i + 1
+		}
+	}
+
+	layout := make(map[int]InputColumn, len(positionalLayout))
+	for column, field := range positionalLayout {
+		layout[column] = field
+	}
+	return layout, 0
+}
+
+func blankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func truthy(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "y", "yes", "x", "true", "1", "48v", "+48v", "+48", "on":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+package rider
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrFixtureNameMissing = errors.New("lighting fixtures need a name")
+	ErrVideoNameMissing   = errors.New("video displays need a name")
+	ErrHazePolicyInvalid  = errors.New(`haze must be "required", "preferred" or "none"`)
+	ErrUniverseInvalid    = errors.New("DMX universes must be positive")
+)
+
+type HazePolicy string
+
+const (
+	HazeRequired  HazePolicy = "required"
+	HazePreferred HazePolicy = "preferred"
+	// HazeNone means no haze or fog at all, usually for a performer's
+	// health.
+	HazeNone HazePolicy = "none"
+)
+
+func (h HazePolicy) String() string {
+	switch h {
+	case HazeRequired:
+		return "Haze is required"
+	case HazePreferred:
+		return "Haze is preferred if the venue allows it"
+	case HazeNone:
+		return "No haze or fog, please"
+	}
+	return string(h)
+}
+
+type Lighting struct {
+	Fixtures []Fixture `json:"fixtures"`
+	// HouseRig is what the band needs from the venue's own rig, e.g. a
+	// full front wash.
+	HouseRig string `json:"house_rig"`
+	// Universes is how many DMX universes the band's show file drives.
+	Universes int    `json:"universes"`
+	Protocol  string `json:"protocol"`
+	Console   string `json:"console"`
+	// ConsoleProvidedBy is left empty when the band doesn't run its own
+	// show.
+	ConsoleProvidedBy ProvidedBy     `json:"console_provided_by,omitempty"`
+	Haze              HazePolicy     `json:"haze,omitempty"`
+	Video             []VideoDisplay `json:"video"`
+	Notes             string         `json:"notes"`
+}
+
+type Fixture struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Quantity int    `json:"quantity"`
+	// Mode is the DMX personality, e.g. "16ch extended".
+	Mode       string     `json:"mode"`
+	Universe   int        `json:"universe,omitempty"`
+	ProvidedBy ProvidedBy `json:"provided_by"`
+	Notes      string     `json:"notes"`
+}
+
+func (f Fixture) count() int {
+	return max(f.Quantity, 1)
+}
+
+// VideoDisplay is a projector, LED wall or screen the band puts content on.
+type VideoDisplay struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	// Input is the connection the band's playback feeds, e.g. "SDI" or
+	// "HDMI".
+	Input      string     `json:"input"`
+	Resolution string     `json:"resolution"`
+	ProvidedBy ProvidedBy `json:"provided_by"`
+	Notes      string     `json:"notes"`
+}
+
+func (l *Lighting) validate() error {
+	for _, fixture := range l.Fixtures {
+		if strings.TrimSpace(fixture.Name) == "" {
+			return ErrFixtureNameMissing
+		} else if fixture.Quantity < 0 {
+			return fmt.Errorf("%w: %s", ErrQuantityInvalid, fixture.Name)
+		} else if fixture.Universe < 0 {
+			return fmt.Errorf("%w: %s", ErrUniverseInvalid, fixture.Name)
+		}
+		err := fixture.ProvidedBy.validate()
+		if err != nil {
+			return err
+		}
+	}
+	for _, display := range l.Video {
+		if strings.TrimSpace(display.Name) == "" {
+			return ErrVideoNameMissing
+		}
+		err := display.ProvidedBy.validate()
+		if err != nil {
+			return err
+		}
+	}
+	err := validateLineIDs("lighting fixtures", len(l.Fixtures), func(i int) string { return l.Fixtures[i].ID })
+	if err != nil {
+		return err
+	}
+	err = validateLineIDs("video", len(l.Video), func(i int) string { return l.Video[i].ID })
+	if err != nil {
+		return err
+	}
+	if l.Universes < 0 {
+		return ErrUniverseInvalid
+	}
+	if l.ConsoleProvidedBy != "" {
+		err := l.ConsoleProvidedBy.validate()
+		if err != nil {
+			return err
+		}
+	}
+	switch l.Haze {
+	case "", HazeRequired, HazePreferred, HazeNone:
+	default:
+		return fmt.Errorf("%w: got %q", ErrHazePolicyInvalid, l.Haze)
+	}
+	return nil
+}
+
+func (l *Lighting) sections() []Section {
+	lighting := Section{
+		Title:   "Lighting",
+		Headers: []string{"Fixture", "Qty", "Type", "Mode", "Universe", "Provided by"},
+	}
+	if l.HouseRig != "" {
+		lighting.Lines = append(lighting.Lines, "House rig: "+l.HouseRig)
+	}
+	if l.Console != "" || l.Universes > 0 {
+		control := fmt.Sprintf("Control: %d DMX universe(s)", l.Universes)
+		if l.Protocol != "" {
+			control += " over " + l.Protocol
+		}
+		if l.Console != "" {
+			control += fmt.Sprintf(", %s", l.Console)
+			if l.ConsoleProvidedBy != "" {
+				control += fmt.Sprintf(" (%s)", l.ConsoleProvidedBy)
+			}
+		}
+		lighting.Lines = append(lighting.Lines, control)
+	}
+	if l.Haze != "" {
+		lighting.Lines = append(lighting.Lines, l.Haze.String())
+	}
+	if strings.TrimSpace(l.Notes) != "" {
+		lighting.Lines = append(lighting.Lines, strings.Split(l.Notes, "\n")...)
+	}
+	for i, fixture := range l.Fixtures {
+		universe := ""
+		if fixture.Universe > 0 {
+			universe = strconv.Itoa(fixture.Universe)
+		}
+		lighting.Rows = append(lighting.Rows, []string{
+			fixture.Name,
+			strconv.Itoa(fixture.count()),
+			fixture.Type,
+			fixture.Mode,
+			universe,
+			fixture.ProvidedBy.String(),
+		})
+		lighting.RowKeys = append(lighting.RowKeys, fmt.Sprintf("lighting:%d", i+1))
+	}
+	if len(lighting.Rows) == 0 {
+		lighting.Headers = nil
+	}
+
+	sections := []Section{lighting}
+	if len(l.Video) > 0 {
+		video := Section{
+			Title:   "Video",
+			Headers: []string{"Display", "Input", "Resolution", "Provided by", "Notes"},
+		}
+		for i, display := range l.Video {
+			video.Rows = append(video.Rows, []string{
+				display.Name,
+				display.Input,
+				display.Resolution,
+				display.ProvidedBy.String(),
+				display.Notes,
+			})
+			video.RowKeys = append(video.RowKeys, fmt.Sprintf("video:%d", i+1))
+		}
+		sections = append(sections, video)
+	}
+	return sections
+}
@@ -0,0 +1,57 @@
+package rider
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrLineIDDuplicate = errors.New("line ids must be unique within a section")
+
+// WithLineIDs gives every backline, lighting and video line without an id
+// the next unused one for its section. A line keeps its id as others are
+// added, removed or reordered, so show overrides and packing check offs can
+// refer to it.
+func (d Document) WithLineIDs() Document {
+	d.Backline = append([]BacklineItem(nil), d.Backline...)
+	assignLineIDs("backline", len(d.Backline), func(i int) *string { return &d.Backline[i].ID })
+	if d.Lighting != nil {
+		lighting := *d.Lighting
+		lighting.Fixtures = append([]Fixture(nil), lighting.Fixtures...)
+		lighting.Video = append([]VideoDisplay(nil), lighting.Video...)
+		assignLineIDs("fixture", len(lighting.Fixtures), func(i int) *string { return &lighting.Fixtures[i].ID })
+		assignLineIDs("video", len(lighting.Video), func(i int) *string { return &lighting.Video[i].ID })
+		d.Lighting = &lighting
+	}
+	return d
+}
+
+func assignLineIDs(prefix string, n int, id func(i int) *string) {
+	next := 1
+	for i := range n {
+		suffix, ok := strings.CutPrefix(*id(i), prefix+"-")
+		if number, err := strconv.Atoi(suffix); ok && err == nil && number >= next {
+			next = number + 1
+		}
+	}
+	for i := range n {
+		if *id(i) == "" {
+			*id(i) = fmt.Sprintf("%s-%d", prefix, next)
+			next++
+		}
+	}
+}
+
+func validateLineIDs(section string, n int, id func(i int) string) error {
+	seen := make(map[string]bool, n)
+	for i := range n {
+		if id(i) == "" {
+			continue
+		} else if seen[id(i)] {
+			return fmt.Errorf("%w: %s has %q more than once", ErrLineIDDuplicate, section, id(i))
+		}
+		seen[id(i)] = true
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package rider
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrMixNumberInvalid   = errors.New("monitor mix numbers must be positive")
+	ErrMixNumberDuplicate = errors.New("monitor mix numbers must be unique")
+	ErrMixKindInvalid     = errors.New(`monitor mixes must be a "wedge" or "iem" mix`)
+	ErrMixChannelUnknown  = errors.New("monitor mixes can only contain channels from the input list")
+)
+
+type MixKind string
+
+const (
+	MixKindWedge MixKind = "wedge"
+	MixKindIEM   MixKind = "iem"
+)
+
+type Monitors struct {
+	// BandBringsIEMRack means the band's own IEM transmitters take the IEM
+	// mixes from the desk, so the venue only needs to supply the sends.
+	BandBringsIEMRack bool         `json:"band_brings_iem_rack"`
+	Mixes             []MonitorMix `json:"mixes"`
+	Notes             string       `json:"notes"`
+}
+
+type MonitorMix struct {
+	Number     int       `json:"number"`
+	Kind       MixKind   `json:"kind"`
+	Stereo     bool      `json:"stereo"`
+	Performers []string  `json:"performers"`
+	Contents   []MixSend `json:"contents"`
+	Notes      string    `json:"notes"`
+}
+
+// MixSend puts an input list channel into a mix. Level is however the
+// performer describes it, e.g. "lots" or "just a touch".
+type MixSend struct {
+	Channel int    `json:"channel"`
+	Level   string `json:"level"`
+}
+
+func (m *Monitors) validate(inputList []Channel) error {
+	channels := make(map[int]bool, len(inputList))
+	for _, ch := range inputList {
+		channels[ch.Number] = true
+	}
+
+	seen := make(map[int]bool, len(m.Mixes))
+	for _, mix := range m.Mixes {
+		if mix.Number <= 0 {
+			return fmt.Errorf("%w: got %d", ErrMixNumberInvalid, mix.Number)
+		} else if seen[mix.Number] {
+			return fmt.Errorf("%w: %d appears more than once", ErrMixNumberDuplicate, mix.Number)
+		} else if mix.Kind != MixKindWedge && mix.Kind != MixKindIEM {
+			return fmt.Errorf("%w: got %q", ErrMixKindInvalid, mix.Kind)
+		}
+		seen[mix.Number] = true
+
+		for _, send := range mix.Contents {
+			if !channels[send.Channel] {
+				return fmt.Errorf("%w: mix %d wants channel %d", ErrMixChannelUnknown, mix.Number, send.Channel)
+			}
+		}
+	}
+	return nil
+}
+
+func (m MonitorMix) label() string {
+	kind := "Wedge"
+	if m.Kind == MixKindIEM {
+		kind = "IEM"
+	}
+	if m.Stereo {
+		kind += " (stereo)"
+	}
+	return kind
+}
+
+// MonitorMatrix lays the monitor mixes out against the input list, one row
+// per channel and one column per mix, with the requested level where the
+// channel is in the mix.
+func (d Document) MonitorMatrix() ([]string, [][]string) {
+	if d.Monitors == nil || len(d.Monitors.Mixes) == 0 {
+		return nil, nil
+	}
+
+	headers := []string{"Ch", "Source"}
+	for _, mix := range d.Monitors.Mixes {
+		headers = append(headers, fmt.Sprintf("Mix %d", mix.Number))
+	}
+
+	var rows [][]string
+	for _, ch := range d.InputList {
+		row := []string{strconv.Itoa(ch.Number), ch.Source}
+		for _, mix := range d.Monitors.Mixes {
+			cell := ""
+			for _, send := range mix.Contents {
+				if send.Channel == ch.Number {
+					cell = send.Level
+					if cell == "" {
+						cell = "x"
+					}
+				}
+			}
+			row = append(row, cell)
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows
+}
+
+// PerformerChannels maps each performer to the input list channels they play
+// or sing into.
+func (d Document) PerformerChannels() map[string][]int {
+	channels := map[string][]int{}
+	for _, ch := range d.InputList {
+		if ch.Performer != "" {
+			channels[ch.Performer] = append(channels[ch.Performer], ch.Number)
+		}
+	}
+	return channels
+}
+
+func (d Document) monitorSections() []Section {
+	mixes := Section{
+		Title:   "Monitor mixes",
+		Headers: []string{"Mix", "Type", "Performers", "Contents", "Notes"},
+	}
+	if d.Monitors.BandBringsIEMRack {
+		mixes.Lines = append(mixes.Lines, "The band brings its own IEM rack, please provide sends for each IEM mix.")
+	}
+	if d.Monitors.Notes != "" {
+		mixes.Lines = append(mixes.Lines, strings.Split(d.Monitors.Notes, "\n")...)
+	}
+
+	performers := d.PerformerChannels()
+	for _, mix := range d.Monitors.Mixes {
+		contents := make([]string, 0, len(mix.Contents))
+		for _, send := range mix.Contents {
+			content := fmt.Sprintf("ch %d", send.Channel)
+			if send.Level != "" {
+				content = fmt.Sprintf("%s (%s)", content, send.Level)
+			}
+			contents = append(contents, content)
+		}
+
+		mixes.Rows = append(mixes.Rows, []string{
+			strconv.Itoa(mix.Number),
+			mix.label(),
+			strings.Join(mix.Performers, ", "),
+			strings.Join(contents, ", "),
+			mix.Notes,
+		})
+		mixes.RowKeys = append(mixes.RowKeys, fmt.Sprintf("monitor:%d", mix.Number))
+
+		for _, performer := range mix.Performers {
+			if _, ok := performers[performer]; !ok {
+				performers[performer] = nil
+			}
+		}
+	}
+	sections := []Section{mixes}
+
+	if len(performers) > 0 {
+		section := Section{
+			Title:   "Performers",
+			Headers: []string{"Performer", "Mixes", "Channels"},
+		}
+		names := make([]string, 0, len(performers))
+		for name := range performers {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			var mixNumbers []string
+			for _, mix := range d.Monitors.Mixes {
+				if slices.Contains(mix.Performers, name) {
+					mixNumbers = append(mixNumbers, strconv.Itoa(mix.Number))
+				}
+			}
+			var channels []string
+			for _, number := range performers[name] {
+				channels = append(channels, strconv.Itoa(number))
+			}
+			section.Rows = append(section.Rows, []string{name, strings.Join(mixNumbers, ", "), strings.Join(channels, ", ")})
+		}
+		sections = append(sections, section)
+	}
+
+	headers, rows := d.MonitorMatrix()
+	if len(rows) > 0 {
+		sections = append(sections, Section{
+			Title:   "Monitor matrix",
+			Headers: headers,
+			Rows:    rows,
+		})
+	}
+	return sections
+}
@@ -0,0 +1,98 @@
+package rider
+
+import "fmt"
+
+// PackingItem is something the band has to get into the truck for a show.
+type PackingItem struct {
+	// Key is what check offs are stored against. Inventory items use their
+	// gear id, so one that's on several lines of the rider is only packed
+	// once, and other lines use their line id, which stays with them as the
+	// rider changes. Lines saved before line ids existed fall back to their
+	// position, which does move when lines are added or reordered.
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Section  string `json:"section"`
+	Quantity int    `json:"quantity"`
+	GearID   int32  `json:"gear_id,omitempty"`
+}
+
+// Packing lists everything in the rider that the band brings: every backline,
+// lighting and video line marked as band brings, and the inventory items on
+// the input list and stage plot.
+func (d Document) Packing() []PackingItem {
+	items := []PackingItem{}
+	seen := map[string]bool{}
+	add := func(item PackingItem) {
+		if item.GearID != 0 {
+			item.Key = fmt.Sprintf("gear:%d", item.GearID)
+		}
+		if seen[item.Key] {
+			return
+		}
+		seen[item.Key] = true
+		items = append(items, item)
+	}
+
+	for _, ch := range d.InputList {
+		if ch.GearID == 0 {
+			continue
+		}
+		add(PackingItem{Name: ch.Mic, Section: "Input list", Quantity: 1, GearID: ch.GearID})
+	}
+	if d.StagePlot != nil {
+		for _, item := range d.StagePlot.Items {
+			if item.GearID == 0 {
+				continue
+			}
+			add(PackingItem{Name: item.name(), Section: "Stage plot", Quantity: 1, GearID: item.GearID})
+		}
+	}
+	for i, item := range d.Backline {
+		if item.ProvidedBy != ProvidedByBand {
+			continue
+		}
+		add(PackingItem{
+			Key:      lineKey("backline", item.ID, i),
+			Name:     item.Name,
+			Section:  "Backline",
+			Quantity: item.count(),
+			GearID:   item.GearID,
+		})
+	}
+	if l := d.Lighting; l != nil {
+		for i, fixture := range l.Fixtures {
+			if fixture.ProvidedBy != ProvidedByBand {
+				continue
+			}
+			add(PackingItem{
+				Key:      lineKey("lighting", fixture.ID, i),
+				Name:     fixture.Name,
+				Section:  "Lighting",
+				Quantity: fixture.count(),
+			})
+		}
+		if l.Console != "" && l.ConsoleProvidedBy == ProvidedByBand {
+			add(PackingItem{Key: "lighting:console", Name: l.Console, Section: "Lighting", Quantity: 1})
+		}
+		for i, display := range l.Video {
+			if display.ProvidedBy != ProvidedByBand {
+				continue
+			}
+			add(PackingItem{
+				Key:      lineKey("video", display.ID, i),
+				Name:     display.Name,
+				Section:  "Video",
+				Quantity: 1,
+			})
+		}
+	}
+	return items
+}
+
+// lineKey keys a line by its id, or by its position for a line without one.
+func lineKey(section, id string, i int) string {
+	if id != "" {
+		return section + ":" + id
+	}
+	return fmt.Sprintf("%s:%d", section, i+1)
+}
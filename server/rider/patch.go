@@ -0,0 +1,238 @@
+package rider
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrPatchInvalid is wrapped by every error that comes from the patch itself
+// rather than from reading the rider.
+var ErrPatchInvalid = errors.New("invalid patch")
+
+// listKeys names the lists that a patch can change one item at a time, and
+// how each item is keyed. Lists are found by their path of JSON keys from the
+// top of the document.
+var listKeys = map[string]listKey{
+	"input_list":        fieldKey("number", true),
+	"monitors.mixes":    fieldKey("number", true),
+	"stage_plot.items":  fieldKey("id", false),
+	"patch.stage_boxes": fieldKey("name", false),
+	"patch.assignments": fieldKey("channel", true),
+	// a planned frequency shouldn't bring back a device that has since
+	// been taken off the rider, so devices can't be added by key
+	"wireless.devices":  {of: wirelessKey},
+	"backline":          fieldKey("id", false),
+	"lighting.fixtures": fieldKey("id", false),
+	"lighting.video":    fieldKey("id", false),
+}
+
+type listKey struct {
+	of func(item map[string]any) string
+	// set fills in the key of an item that a patch adds. Without it,
+	// changes to items the list doesn't have are left out.
+	set func(item map[string]any, key string) error
+}
+
+func fieldKey(field string, numeric bool) listKey {
+	of := func(item map[string]any) string {
+		switch v := item[field].(type) {
+		case float64:
+			if numeric {
+				return strconv.FormatFloat(v, 'f', -1, 64)
+			}
+		case string:
+			if !numeric {
+				return v
+			}
+		}
+		return ""
+	}
+	set := func(item map[string]any, key string) error {
+		if !numeric {
+			item[field] = key
+			return nil
+		}
+		n, err := strconv.ParseFloat(key, 64)
+		if err != nil {
+			return fmt.Errorf("%q isn't a %s", key, field)
+		}
+		item[field] = n
+		return nil
+	}
+	return listKey{of, set}
+}
+
+var wirelessKeyFields = map[WirelessKind]string{
+	WirelessKindMic: "channel",
+	WirelessKindIEM: "mix",
+}
+
+// wirelessKey keys a mic by the channel it comes up on and an IEM by the mix
+// it carries, e.g. "mic:5" or "iem:2".
+func wirelessKey(item map[string]any) string {
+	kind, _ := item["kind"].(string)
+	field := wirelessKeyFields[WirelessKind(kind)]
+	if n, ok := item[field].(float64); ok && n > 0 {
+		return kind + ":" + strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return ""
+}
+
+// ApplyPatch layers a JSON merge patch (RFC 7396) over a document. Objects
+// are merged key by key and a null removes a key. An array in the patch
+// replaces the list whole, but the lists in listKeys can instead be patched
+// with an object keyed by item, e.g. {"input_list": {"12": null}} to drop
+// channel 12 or {"input_list": {"3": {"mic": "Beta 52"}}} to swap one mic.
+// Only the items named are touched, so the rest keep following the rider.
+func ApplyPatch(base Document, patch json.RawMessage) (Document, error) {
+	if len(patch) == 0 {
+		return base, nil
+	}
+
+	raw, err := json.Marshal(base)
+	if err != nil {
+		return Document{}, err
+	}
+
+	var target, changes any
+	err = json.Unmarshal(raw, &target)
+	if err != nil {
+		return Document{}, err
+	}
+	err = json.Unmarshal(patch, &changes)
+	if err != nil {
+		return Document{}, fmt.Errorf("%w: %v", ErrPatchInvalid, err)
+	}
+	if _, ok := changes.(map[string]any); !ok {
+		return Document{}, fmt.Errorf("%w: must be a JSON object", ErrPatchInvalid)
+	}
+
+	result, err := mergePatch(target, changes, "")
+	if err != nil {
+		return Document{}, fmt.Errorf("%w: %v", ErrPatchInvalid, err)
+	}
+	merged, err := json.Marshal(result)
+	if err != nil {
+		return Document{}, err
+	}
+
+	var doc Document
+	err = json.Unmarshal(merged, &doc)
+	if err != nil {
+		return Document{}, fmt.Errorf("%w: it does not produce a valid rider: %v", ErrPatchInvalid, err)
+	}
+	return doc, nil
+}
+
+func mergePatch(target, patch any, path string) (any, error) {
+	changes, ok := patch.(map[string]any)
+	if !ok {
+		return patch, nil
+	}
+	if list, ok := target.([]any); ok {
+		return patchList(list, changes, path)
+	} else if _, keyed := listKeys[path]; keyed && target == nil {
+		return patchList(nil, changes, path)
+	}
+
+	result, ok := target.(map[string]any)
+	if !ok {
+		result = map[string]any{}
+	}
+	for key, value := range changes {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		merged, err := mergePatch(result[key], value, joinPath(path, key))
+		if err != nil {
+			return nil, err
+		}
+		result[key] = merged
+	}
+	return result, nil
+}
+
+// patchList applies changes keyed by item to one of the lists in listKeys.
+// Items keep their order, and items the rider doesn't have yet are added to
+// the end in key order where the list allows it.
+func patchList(list []any, changes map[string]any, path string) ([]any, error) {
+	listKey, ok := listKeys[path]
+	if !ok {
+		return nil, fmt.Errorf("%s can only be replaced whole", path)
+	}
+
+	result := make([]any, 0, len(list))
+	applied := map[string]bool{}
+	for _, item := range list {
+		fields, _ := item.(map[string]any)
+		key := listKey.of(fields)
+		change, ok := changes[key]
+		if key == "" || !ok {
+			result = append(result, item)
+			continue
+		}
+		applied[key] = true
+		if change == nil {
+			continue
+		}
+		merged, err := mergePatch(item, change, path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, merged)
+	}
+
+	added := make([]string, 0, len(changes))
+	for key := range changes {
+		if listKey.set != nil && !applied[key] && changes[key] != nil {
+			added = append(added, key)
+		}
+	}
+	sortKeys(added)
+	for _, key := range added {
+		item, ok := changes[key].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s %q must be an object", path, key)
+		}
+		merged, err := mergePatch(map[string]any{}, item, path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		err = listKey.set(merged.(map[string]any), key)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		result = append(result, merged)
+	}
+	return result, nil
+}
+
+// sortKeys puts numeric keys in number order and the rest after them.
+func sortKeys(keys []string) {
+	slices.SortFunc(keys, func(a, b string) int {
+		na, errA := strconv.ParseFloat(a, 64)
+		nb, errB := strconv.ParseFloat(b, 64)
+		switch {
+		case errA == nil && errB == nil:
+			return cmp.Compare(na, nb)
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,278 @@
+package rider
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrPowerDropIDMissing   = errors.New("power drops need an id")
+	ErrPowerDropDuplicate   = errors.New("power drop ids must be unique")
+	ErrPowerDropInvalid     = errors.New("power drops need a voltage and an amp rating")
+	ErrPowerDropUnknown     = errors.New("stage plot items can only be plugged into a listed power drop")
+	ErrCircuitIDMissing     = errors.New("circuits need an id")
+	ErrCircuitDuplicate     = errors.New("circuit ids must be unique")
+	ErrCircuitUnknown       = errors.New("power drops can only be on a listed circuit")
+	ErrPowerNeedNegative    = errors.New("voltages and wattages can't be negative")
+	ErrCircuitRatingInvalid = errors.New("circuits need an amp rating")
+)
+
+// continuousLoadLimit is the share of a breaker's rating that a load running
+// for the whole show should stay under.
+const continuousLoadLimit = 0.8
+
+// PowerNeed is what a stage plot item draws and how it plugs in.
+type PowerNeed struct {
+	// Voltage is left at zero for gear with a universal power supply.
+	Voltage   int    `json:"voltage"`
+	Watts     int    `json:"watts"`
+	Connector string `json:"connector"`
+	// Drop is the id of the power drop the item plugs into.
+	Drop string `json:"drop"`
+}
+
+func (p PowerNeed) String() string {
+	var parts []string
+	if p.Watts > 0 {
+		parts = append(parts, fmt.Sprintf("%dW", p.Watts))
+	}
+	if p.Voltage > 0 {
+		parts = append(parts, fmt.Sprintf("%dV", p.Voltage))
+	}
+	if p.Connector != "" {
+		parts = append(parts, p.Connector)
+	}
+	if p.Drop != "" {
+		parts = append(parts, fmt.Sprintf("on %s", p.Drop))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// PowerDrop is an outlet box the venue runs to a spot on the stage.
+type PowerDrop struct {
+	ID        string  `json:"id"`
+	Label     string  `json:"label"`
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	Voltage   int     `json:"voltage"`
+	Amps      float64 `json:"amps"`
+	Connector string  `json:"connector"`
+	// Circuit is the id of the breaker feeding the drop, when several
+	// drops share one.
+	Circuit string `json:"circuit"`
+}
+
+func (d PowerDrop) name() string {
+	if d.Label != "" {
+		return d.Label
+	}
+	return d.ID
+}
+
+type Circuit struct {
+	ID    string  `json:"id"`
+	Label string  `json:"label"`
+	Amps  float64 `json:"amps"`
+}
+
+func (p *StagePlot) validatePower() error {
+	circuits := make(map[string]bool, len(p.Circuits))
+	for _, circuit := range p.Circuits {
+		if strings.TrimSpace(circuit.ID) == "" {
+			return ErrCircuitIDMissing
+		} else if circuits[circuit.ID] {
+			return fmt.Errorf("%w: %q appears more than once", ErrCircuitDuplicate, circuit.ID)
+		} else if circuit.Amps <= 0 {
+			return fmt.Errorf("%w: %s", ErrCircuitRatingInvalid, circuit.ID)
+		}
+		circuits[circuit.ID] = true
+	}
+
+	drops := make(map[string]bool, len(p.Drops))
+	for _, drop := range p.Drops {
+		if strings.TrimSpace(drop.ID) == "" {
+			return ErrPowerDropIDMissing
+		} else if drops[drop.ID] {
+			return fmt.Errorf("%w: %q appears more than once", ErrPowerDropDuplicate, drop.ID)
+		} else if drop.Voltage <= 0 || drop.Amps <= 0 {
+			return fmt.Errorf("%w: %s", ErrPowerDropInvalid, drop.ID)
+		} else if drop.Circuit != "" && !circuits[drop.Circuit] {
+			return fmt.Errorf("%w: %s is on %q", ErrCircuitUnknown, drop.ID, drop.Circuit)
+		}
+		drops[drop.ID] = true
+	}
+
+	for _, item := range p.Items {
+		if item.Power == nil {
+			continue
+		} else if item.Power.Voltage < 0 || item.Power.Watts < 0 {
+			return fmt.Errorf("%w: %s", ErrPowerNeedNegative, item.ID)
+		} else if item.Power.Drop != "" && !drops[item.Power.Drop] {
+			return fmt.Errorf("%w: %s wants %q", ErrPowerDropUnknown, item.ID, item.Power.Drop)
+		}
+	}
+	return nil
+}
+
+// PowerLoad is the total draw on a drop or circuit.
+type PowerLoad struct {
+	ID      string   `json:"id"`
+	Label   string   `json:"label"`
+	Voltage int      `json:"voltage,omitempty"`
+	Watts   int      `json:"watts"`
+	Amps    float64  `json:"amps"`
+	Rating  float64  `json:"rating_amps"`
+	Items   []string `json:"items"`
+}
+
+func (l PowerLoad) over() bool {
+	return l.Amps > l.Rating
+}
+
+func (l PowerLoad) nearLimit() bool {
+	return l.Amps > l.Rating*continuousLoadLimit
+}
+
+type PowerReport struct {
+	Drops    []PowerLoad `json:"drops"`
+	Circuits []PowerLoad `json:"circuits"`
+	// Unassigned lists items that need power but aren't plugged into a
+	// drop, so aren't counted in any load.
+	Unassigned []string `json:"unassigned"`
+	Warnings   []string `json:"warnings"`
+}
+
+// Power adds up the load on each drop and circuit of the stage plot and
+// warns about anything likely to trip a breaker or need an adapter.
+func (d Document) Power() PowerReport {
+	report := PowerReport{
+		Drops:      []PowerLoad{},
+		Circuits:   []PowerLoad{},
+		Unassigned: []string{},
+		Warnings:   []string{},
+	}
+	if d.StagePlot == nil {
+		return report
+	}
+	p := d.StagePlot
+
+	drops := make(map[string]int, len(p.Drops))
+	for i, drop := range p.Drops {
+		drops[drop.ID] = i
+		report.Drops = append(report.Drops, PowerLoad{
+			ID:      drop.ID,
+			Label:   drop.name(),
+			Voltage: drop.Voltage,
+			Rating:  drop.Amps,
+			Items:   []string{},
+		})
+	}
+
+	for _, item := range p.Items {
+		if item.Power == nil {
+			continue
+		}
+		i, ok := drops[item.Power.Drop]
+		if !ok {
+			report.Unassigned = append(report.Unassigned, item.name())
+			continue
+		}
+		drop := p.Drops[i]
+		load := &report.Drops[i]
+		load.Watts += item.Power.Watts
+		load.Items = append(load.Items, item.name())
+
+		if item.Power.Voltage != 0 && item.Power.Voltage != drop.Voltage {
+			report.Warnings = append(report.Warnings, fmt.Sprintf(
+				"%s needs %dV but %s supplies %dV, so it needs a transformer", item.name(), item.Power.Voltage, drop.name(), drop.Voltage,
+			))
+		}
+		if item.Power.Connector != "" && drop.Connector != "" && !strings.EqualFold(item.Power.Connector, drop.Connector) {
+			report.Warnings = append(report.Warnings, fmt.Sprintf(
+				"%s has a %s plug but %s has %s outlets, so it needs an adapter", item.name(), item.Power.Connector, drop.name(), drop.Connector,
+			))
+		}
+	}
+
+	circuits := make(map[string]int, len(p.Circuits))
+	for i, circuit := range p.Circuits {
+		circuits[circuit.ID] = i
+		label := circuit.Label
+		if label == "" {
+			label = circuit.ID
+		}
+		report.Circuits = append(report.Circuits, PowerLoad{
+			ID:     circuit.ID,
+			Label:  label,
+			Rating: circuit.Amps,
+			Items:  []string{},
+		})
+	}
+
+	for i := range report.Drops {
+		load := &report.Drops[i]
+		load.Amps = amps(load.Watts, load.Voltage)
+		report.Warnings = append(report.Warnings, load.warning("Drop")...)
+
+		j, ok := circuits[p.Drops[i].Circuit]
+		if !ok {
+			continue
+		}
+		circuit := &report.Circuits[j]
+		circuit.Watts += load.Watts
+		circuit.Amps = round(circuit.Amps + load.Amps)
+		circuit.Items = append(circuit.Items, load.Items...)
+	}
+	for _, circuit := range report.Circuits {
+		report.Warnings = append(report.Warnings, circuit.warning("Circuit")...)
+	}
+	for _, name := range report.Unassigned {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%s needs power but isn't on a drop", name))
+	}
+	return report
+}
+
+func (l PowerLoad) warning(kind string) []string {
+	if l.over() {
+		return []string{fmt.Sprintf("%s %s draws %.1fA, over its %.0fA rating", kind, l.Label, l.Amps, l.Rating)}
+	} else if l.nearLimit() {
+		return []string{fmt.Sprintf("%s %s draws %.1fA, over %.0f%% of its %.0fA rating", kind, l.Label, l.Amps, continuousLoadLimit*100, l.Rating)}
+	}
+	return nil
+}
+
+func amps(watts, voltage int) float64 {
+	if voltage <= 0 {
+		return 0
+	}
+	return round(float64(watts) / float64(voltage))
+}
+
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+func (p *StagePlot) powerSection() Section {
+	report := Document{StagePlot: p}.Power()
+	section := Section{
+		Title:   "Power",
+		Headers: []string{"Drop", "Circuit", "Voltage", "Connector", "Load", "Rating"},
+		Lines:   report.Warnings,
+	}
+	for i, drop := range p.Drops {
+		load := report.Drops[i]
+		section.Rows = append(section.Rows, []string{
+			drop.name(),
+			drop.Circuit,
+			strconv.Itoa(drop.Voltage) + "V",
+			drop.Connector,
+			fmt.Sprintf("%dW / %.1fA", load.Watts, load.Amps),
+			fmt.Sprintf("%.0fA", drop.Amps),
+		})
+		section.RowKeys = append(section.RowKeys, fmt.Sprintf("power:%s", drop.ID))
+	}
+	return section
+}
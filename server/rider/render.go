@@ -0,0 +1,214 @@
+package rider
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jkellogg01/rider/server/pdf"
+	"github.com/jkellogg01/rider/server/symbol"
+)
+
+// Section is a format-agnostic view of part of a rider, shared by the HTML
+// share page and the PDF export so they always agree on what a rider says.
+type Section struct {
+	Title   string
+	Lines   []string
+	Headers []string
+	Rows    [][]string
+	// RowKeys identifies each row as a line item that a venue can respond
+	// to. It is either empty or the same length as Rows.
+	RowKeys []string
+	Plot    *Plot
+}
+
+// Plot is a top down drawing of the stage, in metres from the back left
+// corner as seen from the audience.
+type Plot struct {
+	Width   float64
+	Depth   float64
+	Boxes   []PlotBox
+	Markers []PlotMarker
+}
+
+// PlotBox is drawn as the symbol for its type, or as a plain box when there
+// isn't one.
+type PlotBox struct {
+	X, Y, Width, Depth float64
+	Label              string
+	Type               string
+	Symbol             *symbol.Symbol
+}
+
+// PlotMarker is a power drop, drawn at markerSize around its point.
+type PlotMarker struct {
+	X, Y   float64
+	Label  string
+	Symbol *symbol.Symbol
+}
+
+// markerSize is how big marker symbols are drawn, in metres.
+const markerSize = 0.4
+
+// UseSymbols picks the symbol each item in the plot is drawn with from set,
+// which falls back to the built in library.
+func (p *Plot) UseSymbols(set symbol.Set) {
+	for i, box := range p.Boxes {
+		p.Boxes[i].Symbol = set.For(box.Type)
+	}
+	for i := range p.Markers {
+		p.Markers[i].Symbol = set.For("power-drop")
+	}
+}
+
+// WithSymbols applies a band's custom symbols to any plots in sections.
+func WithSymbols(sections []Section, set symbol.Set) []Section {
+	for _, section := range sections {
+		if section.Plot != nil {
+			section.Plot.UseSymbols(set)
+		}
+	}
+	return sections
+}
+
+type LineItem struct {
+	Key   string
+	Label string
+}
+
+// LineItems lists every row of the rider that a venue can flag.
+func (d Document) LineItems() []LineItem {
+	var items []LineItem
+	for _, section := range d.Sections() {
+		for i, key := range section.RowKeys {
+			label := section.Title
+			if row := section.Rows[i]; len(row) > 1 {
+				label = fmt.Sprintf("%s: %s %s", section.Title, row[0], row[1])
+			}
+			items = append(items, LineItem{Key: key, Label: label})
+		}
+	}
+	return items
+}
+
+func (d Document) Sections() []Section {
+	var sections []Section
+	if strings.TrimSpace(d.Notes) != "" {
+		sections = append(sections, Section{
+			Title: "Notes",
+			Lines: strings.Split(d.Notes, "\n"),
+		})
+	}
+	if len(d.InputList) > 0 {
+		sections = append(sections, d.inputListSection())
+	}
+	if d.StagePlot != nil {
+		sections = append(sections, d.StagePlot.sections()...)
+	}
+	if d.Patch != nil && len(d.InputList) > 0 {
+		sections = append(sections, d.patchSection())
+	}
+	if d.Monitors != nil {
+		sections = append(sections, d.monitorSections()...)
+	}
+	if d.Wireless != nil && len(d.Wireless.Devices) > 0 {
+		sections = append(sections, d.Wireless.section())
+	}
+	if len(d.Backline) > 0 {
+		sections = append(sections, d.backlineSection())
+	}
+	if d.Lighting != nil {
+		sections = append(sections, d.Lighting.sections()...)
+	}
+	if d.Hospitality != nil {
+		sections = append(sections, d.Hospitality.sections()...)
+	}
+	return sections
+}
+
+func (d Document) inputListSection() Section {
+	section := Section{
+		Title:   "Input list",
+		Headers: []string{"Ch", "Source", "Mic / DI", "Stand", "48V", "Performer", "Notes"},
+	}
+	for _, ch := range d.InputList {
+		section.Rows = append(section.Rows, []string{
+			strconv.Itoa(ch.Number),
+			ch.Source,
+			ch.Mic,
+			ch.Stand,
+			yesNo(ch.Phantom),
+			ch.Performer,
+			ch.Notes,
+		})
+		section.RowKeys = append(section.RowKeys, fmt.Sprintf("input:%d", ch.Number))
+	}
+	return section
+}
+
+// WritePDF renders the rider, drawing stage plots with the given custom
+// symbols on top of the built in library.
+func WritePDF(w io.Writer, title string, revision int32, d Document, symbols symbol.Set) error {
+	doc := pdf.New()
+	doc.Title(title)
+	doc.Paragraph(fmt.Sprintf("Revision %d", revision))
+	for _, section := range WithSymbols(d.Sections(), symbols) {
+		doc.Heading(section.Title)
+		if len(section.Lines) > 0 {
+			doc.Paragraph(strings.Join(section.Lines, "\n"))
+		}
+		if section.Plot != nil {
+			writePlot(doc, section.Plot)
+		}
+		if len(section.Headers) > 0 || len(section.Rows) > 0 {
+			doc.Table(section.Headers, section.Rows)
+		}
+	}
+	return doc.Write(w)
+}
+
+func writePlot(doc *pdf.Document, plot *Plot) {
+	boxes := make([]pdf.Box, len(plot.Boxes))
+	for i, box := range plot.Boxes {
+		boxes[i] = pdf.Box{X: box.X, Y: box.Y, Width: box.Width, Height: box.Depth, Label: box.Label}
+		if box.Symbol != nil {
+			boxes[i].Shapes = pdfShapes(box.Symbol, box.X, box.Y, box.Width, box.Depth)
+		}
+	}
+	markers := make([]pdf.Marker, len(plot.Markers))
+	for i, marker := range plot.Markers {
+		markers[i] = pdf.Marker{X: marker.X, Y: marker.Y, Label: marker.Label}
+		if marker.Symbol != nil {
+			markers[i].Shapes = pdfShapes(marker.Symbol, marker.X-markerSize/2, marker.Y-markerSize/2, markerSize, markerSize)
+		}
+	}
+	doc.Diagram(plot.Width, plot.Depth, boxes, markers)
+}
+
+// pdfShapes fits a symbol into a box on the plot, in plot coordinates.
+func pdfShapes(sym *symbol.Symbol, x, y, width, depth float64) []pdf.Shape {
+	if width <= 0 || depth <= 0 {
+		return nil
+	}
+	paths := sym.Fit(width, depth)
+	shapes := make([]pdf.Shape, len(paths))
+	for i, path := range paths {
+		shapes[i] = pdf.Shape{Fill: path.Fill, Stroke: path.Stroke}
+		for _, seg := range path.Segments {
+			points := make([]float64, len(seg.Points))
+			for j := 0; j+1 < len(points); j += 2 {
+				points[j], points[j+1] = seg.Points[j]+x, seg.Points[j+1]+y
+			}
+			shapes[i].Segments = append(shapes[i].Segments, pdf.Segment{Op: seg.Op, Points: points})
+		}
+	}
+	return shapes
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
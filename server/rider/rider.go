@@ -0,0 +1,139 @@
+package rider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jkellogg01/rider/server/console"
+)
+
+var (
+	ErrChannelNumberInvalid   = errors.New("input list channel numbers must be positive")
+	ErrChannelNumberDuplicate = errors.New("input list channel numbers must be unique")
+	ErrChannelColorInvalid    = errors.New("input list channel colors must be red, green, yellow, blue, magenta, cyan or white")
+)
+
+// Document is the content of a single rider revision. It is stored as JSON
+// so that sections can be added without reshaping the revision table.
+type Document struct {
+	Notes       string         `json:"notes"`
+	InputList   []Channel      `json:"input_list"`
+	Hospitality *Hospitality   `json:"hospitality,omitempty"`
+	Backline    []BacklineItem `json:"backline"`
+	Monitors    *Monitors      `json:"monitors,omitempty"`
+	Wireless    *Wireless      `json:"wireless,omitempty"`
+	StagePlot   *StagePlot     `json:"stage_plot,omitempty"`
+	Lighting    *Lighting      `json:"lighting,omitempty"`
+	Patch       *Patch         `json:"patch,omitempty"`
+}
+
+type Channel struct {
+	Number  int    `json:"number"`
+	Source  string `json:"source"`
+	Mic     string `json:"mic"`
+	Stand   string `json:"stand"`
+	Phantom bool   `json:"phantom"`
+	Notes   string `json:"notes"`
+	// Performer is who plays or sings into the channel, matching the names
+	// used on monitor mixes.
+	Performer string `json:"performer"`
+	// GearID refers to the band's own mic or DI in the gear inventory.
+	GearID int32 `json:"gear_id,omitempty"`
+	// Color is the channel strip color to set on the desk.
+	Color console.Color `json:"color,omitempty"`
+	// StageItem is the id of the stage plot item the channel is miked or
+	// DI'd from, which places it for patching.
+	StageItem string `json:"stage_item,omitempty"`
+	// CatalogID refers to the mic or DI's entry in the equipment catalog,
+	// which names it the same way on every rider.
+	CatalogID string `json:"catalog_id,omitempty"`
+}
+
+func Parse(raw []byte) (Document, error) {
+	var doc Document
+	if len(raw) == 0 {
+		return doc, nil
+	}
+	err := json.Unmarshal(raw, &doc)
+	return doc, err
+}
+
+func (d Document) Validate() error {
+	seen := make(map[int]bool, len(d.InputList))
+	for _, ch := range d.InputList {
+		if ch.Number <= 0 {
+			return fmt.Errorf("%w: got %d", ErrChannelNumberInvalid, ch.Number)
+		} else if seen[ch.Number] {
+			return fmt.Errorf("%w: %d appears more than once", ErrChannelNumberDuplicate, ch.Number)
+		} else if !ch.Color.Valid() {
+			return fmt.Errorf("%w: channel %d is %q", ErrChannelColorInvalid, ch.Number, ch.Color)
+		}
+		seen[ch.Number] = true
+	}
+	err := d.validateCatalog()
+	if err != nil {
+		return err
+	}
+	if d.Patch != nil {
+		err := d.Patch.validate(d.InputList)
+		if err != nil {
+			return err
+		}
+	}
+	if d.StagePlot != nil {
+		for _, ch := range d.InputList {
+			if ch.StageItem == "" {
+				continue
+			}
+			found := slices.ContainsFunc(d.StagePlot.Items, func(item StageItem) bool {
+				return item.ID == ch.StageItem
+			})
+			if !found {
+				return fmt.Errorf("%w: channel %d is at %q", ErrChannelStageItem, ch.Number, ch.StageItem)
+			}
+		}
+	}
+	for _, item := range d.Backline {
+		err := item.validate()
+		if err != nil {
+			return err
+		}
+	}
+	err = validateLineIDs("backline", len(d.Backline), func(i int) string { return d.Backline[i].ID })
+	if err != nil {
+		return err
+	}
+	if d.Monitors != nil {
+		err := d.Monitors.validate(d.InputList)
+		if err != nil {
+			return err
+		}
+	}
+	if d.StagePlot != nil {
+		err := d.StagePlot.validate()
+		if err != nil {
+			return err
+		}
+	}
+	if d.Wireless != nil {
+		err := d.Wireless.validate()
+		if err != nil {
+			return err
+		}
+	}
+	if d.Lighting != nil {
+		err := d.Lighting.validate()
+		if err != nil {
+			return err
+		}
+	}
+	if d.Hospitality != nil {
+		err := d.Hospitality.validate()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
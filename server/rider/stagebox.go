@@ -0,0 +1,248 @@
+package rider
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrStageBoxNameMissing   = errors.New("stage boxes need a name")
+	ErrStageBoxDuplicate     = errors.New("stage box names must be unique")
+	ErrStageBoxInputsInvalid = errors.New("stage boxes need at least one input")
+	ErrStageBoxTooLarge      = fmt.Errorf("stage boxes can have at most %d inputs", maxStageBoxInputs)
+	ErrPatchChannelUnknown   = errors.New("patch assignments can only be for channels on the input list")
+	ErrPatchBoxUnknown       = errors.New("patch assignments can only use a listed stage box")
+	ErrPatchInputInvalid     = errors.New("patch assignments must use an input the stage box has")
+	ErrPatchInputTaken       = errors.New("stage box inputs can only be patched once")
+	ErrPatchChannelTwice     = errors.New("channels can only be patched once")
+	ErrChannelStageItem      = errors.New("channels can only be placed at an item on the stage plot")
+)
+
+// maxStageBoxInputs is well past the largest stage box or snake made.
+const maxStageBoxInputs = 256
+
+// Patch describes the stage boxes and snakes on stage and which input each
+// channel comes up on. Channels without an assignment are patched
+// automatically.
+type Patch struct {
+	StageBoxes  []StageBox        `json:"stage_boxes"`
+	Assignments []PatchAssignment `json:"assignments"`
+}
+
+// StageBox is a stage box or snake head, positioned on the stage plot.
+type StageBox struct {
+	Name   string  `json:"name"`
+	Inputs int     `json:"inputs"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+}
+
+// PatchAssignment pins a channel to a stage box input by hand.
+type PatchAssignment struct {
+	Channel int    `json:"channel"`
+	Box     string `json:"box"`
+	Input   int    `json:"input"`
+}
+
+func (p *Patch) validate(inputList []Channel) error {
+	boxes := make(map[string]StageBox, len(p.StageBoxes))
+	for _, box := range p.StageBoxes {
+		if strings.TrimSpace(box.Name) == "" {
+			return ErrStageBoxNameMissing
+		} else if _, ok := boxes[box.Name]; ok {
+			return fmt.Errorf("%w: %q appears more than once", ErrStageBoxDuplicate, box.Name)
+		} else if box.Inputs <= 0 {
+			return fmt.Errorf("%w: %s", ErrStageBoxInputsInvalid, box.Name)
+		} else if box.Inputs > maxStageBoxInputs {
+			return fmt.Errorf("%w: %s has %d", ErrStageBoxTooLarge, box.Name, box.Inputs)
+		}
+		boxes[box.Name] = box
+	}
+
+	channels := make(map[int]bool, len(inputList))
+	for _, ch := range inputList {
+		channels[ch.Number] = true
+	}
+	patched := map[int]bool{}
+	taken := map[string]bool{}
+	for _, a := range p.Assignments {
+		box, ok := boxes[a.Box]
+		if !channels[a.Channel] {
+			return fmt.Errorf("%w: got %d", ErrPatchChannelUnknown, a.Channel)
+		} else if !ok {
+			return fmt.Errorf("%w: got %q", ErrPatchBoxUnknown, a.Box)
+		} else if a.Input < 1 || a.Input > box.Inputs {
+			return fmt.Errorf("%w: %s has no input %d", ErrPatchInputInvalid, a.Box, a.Input)
+		} else if patched[a.Channel] {
+			return fmt.Errorf("%w: channel %d", ErrPatchChannelTwice, a.Channel)
+		}
+		key := a.Box + "/" + strconv.Itoa(a.Input)
+		if taken[key] {
+			return fmt.Errorf("%w: %s input %d", ErrPatchInputTaken, a.Box, a.Input)
+		}
+		patched[a.Channel] = true
+		taken[key] = true
+	}
+	return nil
+}
+
+// PatchLine is one row of the patch sheet. Box and Input are empty for a
+// channel that didn't fit on any stage box.
+type PatchLine struct {
+	Channel int    `json:"channel"`
+	Source  string `json:"source"`
+	Box     string `json:"box"`
+	Input   int    `json:"input,omitempty"`
+	// Manual is set for assignments made by hand rather than worked out.
+	Manual bool `json:"manual"`
+	// Distance is how far the box is from the channel's source in metres,
+	// when the source is placed on the stage plot.
+	Distance *float64 `json:"distance,omitempty"`
+}
+
+// PatchSheet assigns every channel to a stage box input. Manual assignments
+// are kept, and each other channel goes to the nearest box to its source on
+// the stage plot that has an input free, taking the lowest free input so
+// neighbouring channels stay together. Channels that aren't placed on the
+// stage plot fill in whatever is left. The second return value describes
+// channels that couldn't be patched, and manual assignments that were left out
+// because their stage box or input no longer exists.
+func (d Document) PatchSheet() ([]PatchLine, []string) {
+	lines := make([]PatchLine, 0, len(d.InputList))
+	warnings := []string{}
+	if d.Patch == nil {
+		return lines, warnings
+	}
+
+	free := make(map[string][]bool, len(d.Patch.StageBoxes))
+	for _, box := range d.Patch.StageBoxes {
+		inputs := min(max(box.Inputs, 0), maxStageBoxInputs)
+		free[box.Name] = make([]bool, inputs+1)
+		for i := 1; i <= inputs; i++ {
+			free[box.Name][i] = true
+		}
+	}
+	manual := map[int]PatchAssignment{}
+	for _, a := range d.Patch.Assignments {
+		inputs, ok := free[a.Box]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("channel %d is patched to %s, which isn't a listed stage box", a.Channel, a.Box))
+			continue
+		} else if a.Input < 1 || a.Input >= len(inputs) {
+			warnings = append(warnings, fmt.Sprintf("channel %d is patched to %s input %d, which the box doesn't have", a.Channel, a.Box, a.Input))
+			continue
+		}
+		manual[a.Channel] = a
+		inputs[a.Input] = false
+	}
+
+	positions := map[string][2]float64{}
+	if d.StagePlot != nil {
+		for _, item := range d.StagePlot.Items {
+			positions[item.ID] = [2]float64{item.X + item.Width/2, item.Y + item.Depth/2}
+		}
+	}
+	distance := func(ch Channel, box StageBox) (float64, bool) {
+		pos, ok := positions[ch.StageItem]
+		if !ok {
+			return 0, false
+		}
+		return math.Round(math.Hypot(pos[0]-box.X, pos[1]-box.Y)*10) / 10, true
+	}
+	lowestFree := func(box StageBox) int {
+		for i := 1; i < len(free[box.Name]); i++ {
+			if free[box.Name][i] {
+				return i
+			}
+		}
+		return 0
+	}
+
+	byChannel := make(map[int]*PatchLine, len(d.InputList))
+	for _, ch := range d.InputList {
+		lines = append(lines, PatchLine{Channel: ch.Number, Source: ch.Source})
+	}
+	for i := range lines {
+		byChannel[lines[i].Channel] = &lines[i]
+	}
+
+	// placed channels go first so they get their pick of the nearby boxes
+	order := slices.Clone(d.InputList)
+	slices.SortStableFunc(order, func(a, b Channel) int {
+		_, aPlaced := positions[a.StageItem]
+		_, bPlaced := positions[b.StageItem]
+		if aPlaced == bPlaced {
+			return cmp.Compare(a.Number, b.Number)
+		} else if aPlaced {
+			return -1
+		}
+		return 1
+	})
+
+	for _, ch := range order {
+		line := byChannel[ch.Number]
+		if a, ok := manual[ch.Number]; ok {
+			line.Box, line.Input, line.Manual = a.Box, a.Input, true
+			for _, box := range d.Patch.StageBoxes {
+				if box.Name == a.Box {
+					if dist, ok := distance(ch, box); ok {
+						line.Distance = &dist
+					}
+				}
+			}
+			continue
+		}
+
+		best := -1
+		bestDistance := math.Inf(1)
+		for i, box := range d.Patch.StageBoxes {
+			if lowestFree(box) == 0 {
+				continue
+			}
+			dist, ok := distance(ch, box)
+			if !ok {
+				// without a position, fill boxes in the order they're listed
+				best = i
+				break
+			}
+			if dist < bestDistance {
+				best, bestDistance = i, dist
+			}
+		}
+		if best < 0 {
+			warnings = append(warnings, fmt.Sprintf("channel %d (%s) doesn't fit on any stage box", ch.Number, ch.Source))
+			continue
+		}
+
+		box := d.Patch.StageBoxes[best]
+		line.Box, line.Input = box.Name, lowestFree(box)
+		free[box.Name][line.Input] = false
+		if dist, ok := distance(ch, box); ok {
+			line.Distance = &dist
+		}
+	}
+	return lines, warnings
+}
+
+func (d Document) patchSection() Section {
+	lines, warnings := d.PatchSheet()
+	section := Section{
+		Title:   "Patch",
+		Headers: []string{"Ch", "Source", "Stage box", "Input"},
+		Lines:   warnings,
+	}
+	for _, line := range lines {
+		input := ""
+		if line.Input > 0 {
+			input = strconv.Itoa(line.Input)
+		}
+		section.Rows = append(section.Rows, []string{strconv.Itoa(line.Channel), line.Source, line.Box, input})
+		section.RowKeys = append(section.RowKeys, fmt.Sprintf("patch:%d", line.Channel))
+	}
+	return section
+}
@@ -0,0 +1,122 @@
+package rider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrStageSizeInvalid     = errors.New("the stage needs a width and depth")
+	ErrStageItemIDMissing   = errors.New("stage plot items need an id")
+	ErrStageItemDuplicate   = errors.New("stage plot item ids must be unique")
+	ErrStageItemSizeInvalid = errors.New("stage plot item sizes can't be negative")
+)
+
+// StagePlot lays out the stage in metres as seen from the audience, with X
+// running from stage left of the audience's view and Y from the back wall
+// towards the front edge.
+type StagePlot struct {
+	Width float64     `json:"width"`
+	Depth float64     `json:"depth"`
+	Items []StageItem `json:"items"`
+	// Drops and Circuits describe the power the band needs run to the
+	// stage.
+	Drops    []PowerDrop `json:"drops"`
+	Circuits []Circuit   `json:"circuits"`
+	Notes    string      `json:"notes"`
+}
+
+// StageItem is something placed on the stage, positioned by its back left
+// corner.
+type StageItem struct {
+	ID    string  `json:"id"`
+	Type  string  `json:"type"`
+	Label string  `json:"label"`
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Width float64 `json:"width"`
+	Depth float64 `json:"depth"`
+	// Power is left out for things that don't plug in.
+	Power  *PowerNeed `json:"power,omitempty"`
+	GearID int32      `json:"gear_id,omitempty"`
+}
+
+func (item StageItem) name() string {
+	if item.Label != "" {
+		return item.Label
+	}
+	return item.ID
+}
+
+func (p *StagePlot) validate() error {
+	if p.Width <= 0 || p.Depth <= 0 {
+		return ErrStageSizeInvalid
+	}
+
+	seen := make(map[string]bool, len(p.Items))
+	for _, item := range p.Items {
+		if strings.TrimSpace(item.ID) == "" {
+			return ErrStageItemIDMissing
+		} else if seen[item.ID] {
+			return fmt.Errorf("%w: %q appears more than once", ErrStageItemDuplicate, item.ID)
+		} else if item.Width < 0 || item.Depth < 0 {
+			return fmt.Errorf("%w: %s", ErrStageItemSizeInvalid, item.ID)
+		}
+		seen[item.ID] = true
+	}
+	return p.validatePower()
+}
+
+func (p *StagePlot) sections() []Section {
+	plot := Section{
+		Title:   "Stage plot",
+		Headers: []string{"Item", "Type", "Position (m)", "Power"},
+		Plot:    p.plot(),
+	}
+	if strings.TrimSpace(p.Notes) != "" {
+		plot.Lines = strings.Split(p.Notes, "\n")
+	}
+	for _, item := range p.Items {
+		power := ""
+		if item.Power != nil {
+			power = item.Power.String()
+		}
+		plot.Rows = append(plot.Rows, []string{
+			item.name(),
+			item.Type,
+			fmt.Sprintf("%.1f, %.1f", item.X, item.Y),
+			power,
+		})
+		plot.RowKeys = append(plot.RowKeys, fmt.Sprintf("stage:%s", item.ID))
+	}
+
+	sections := []Section{plot}
+	if len(p.Drops) > 0 {
+		sections = append(sections, p.powerSection())
+	}
+	return sections
+}
+
+func (p *StagePlot) plot() *Plot {
+	plot := &Plot{Width: p.Width, Depth: p.Depth}
+	for _, item := range p.Items {
+		plot.Boxes = append(plot.Boxes, PlotBox{
+			X:     item.X,
+			Y:     item.Y,
+			Width: item.Width,
+			Depth: item.Depth,
+			Label: item.name(),
+			Type:  item.Type,
+		})
+	}
+	for _, drop := range p.Drops {
+		plot.Markers = append(plot.Markers, PlotMarker{
+			X:     drop.X,
+			Y:     drop.Y,
+			Label: drop.name(),
+		})
+	}
+	plot.UseSymbols(nil)
+	return plot
+}
@@ -0,0 +1,71 @@
+package rider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SupplyItem is something the venue has to get to the stage for a show,
+// either from its own stock or by renting it.
+type SupplyItem struct {
+	Section     string     `json:"section"`
+	Name        string     `json:"name"`
+	Quantity    int        `json:"quantity"`
+	Specs       string     `json:"specs"`
+	Substitutes []string   `json:"substitutes"`
+	ProvidedBy  ProvidedBy `json:"provided_by"`
+}
+
+// VenueSupply lists everything in the rider that the band isn't bringing.
+func (d Document) VenueSupply() []SupplyItem {
+	items := []SupplyItem{}
+	for _, item := range d.Backline {
+		if item.ProvidedBy == ProvidedByBand {
+			continue
+		}
+		items = append(items, SupplyItem{
+			Section:     "Backline",
+			Name:        item.Name,
+			Quantity:    item.count(),
+			Specs:       item.Specs,
+			Substitutes: item.Substitutes,
+			ProvidedBy:  item.ProvidedBy,
+		})
+	}
+	if d.Lighting != nil {
+		for _, fixture := range d.Lighting.Fixtures {
+			if fixture.ProvidedBy == ProvidedByBand {
+				continue
+			}
+			items = append(items, SupplyItem{
+				Section:    "Lighting",
+				Name:       fixture.Name,
+				Quantity:   fixture.count(),
+				Specs:      strings.TrimSpace(fixture.Type + " " + fixture.Mode),
+				ProvidedBy: fixture.ProvidedBy,
+			})
+		}
+		if l := d.Lighting; l.Console != "" && l.ConsoleProvidedBy != "" && l.ConsoleProvidedBy != ProvidedByBand {
+			items = append(items, SupplyItem{
+				Section:    "Lighting",
+				Name:       l.Console,
+				Quantity:   1,
+				Specs:      strings.TrimSpace(fmt.Sprintf("%d DMX universe(s) %s", l.Universes, l.Protocol)),
+				ProvidedBy: l.ConsoleProvidedBy,
+			})
+		}
+		for _, display := range d.Lighting.Video {
+			if display.ProvidedBy == ProvidedByBand {
+				continue
+			}
+			items = append(items, SupplyItem{
+				Section:    "Video",
+				Name:       display.Name,
+				Quantity:   1,
+				Specs:      strings.TrimSpace(display.Input + " " + display.Resolution),
+				ProvidedBy: display.ProvidedBy,
+			})
+		}
+	}
+	return items
+}
@@ -0,0 +1,209 @@
+package rider
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/jkellogg01/rider/server/rf"
+)
+
+var (
+	ErrWirelessKindInvalid    = errors.New(`wireless devices must be a "mic" or "iem"`)
+	ErrWirelessRangeInvalid   = errors.New("wireless tuning ranges need a low end below the high end")
+	ErrWirelessFrequencyRange = errors.New("wireless frequencies must be inside the device's tuning range")
+	ErrWirelessBand           = fmt.Errorf("wireless frequencies must be between %d and %d MHz", minWirelessMHz, maxWirelessMHz)
+	ErrWirelessTooMany        = fmt.Errorf("a rider can have at most %d wireless devices", maxWirelessDevices)
+)
+
+const (
+	// minWirelessMHz and maxWirelessMHz take in every band that wireless
+	// mics and IEMs are sold for, from low VHF up past 2.4 GHz.
+	minWirelessMHz = 30
+	maxWirelessMHz = 3000
+	// maxWirelessDevices is far more than any stage coordinates at once,
+	// and keeps the intermodulation checks from running away.
+	maxWirelessDevices = 256
+)
+
+type WirelessKind string
+
+const (
+	WirelessKindMic WirelessKind = "mic"
+	WirelessKindIEM WirelessKind = "iem"
+)
+
+// Wireless lists the band's transmitters. Frequencies are in MHz, the way
+// they're printed on the hardware.
+type Wireless struct {
+	Devices    []WirelessDevice `json:"devices"`
+	Exclusions []FrequencyRange `json:"exclusions"`
+	// CarrierSpacing and IMSpacing override the coordination defaults, in
+	// MHz.
+	CarrierSpacing float64 `json:"carrier_spacing,omitempty"`
+	IMSpacing      float64 `json:"im_spacing,omitempty"`
+}
+
+type WirelessDevice struct {
+	Name  string       `json:"name"`
+	Kind  WirelessKind `json:"kind"`
+	Model string       `json:"model"`
+	// Channel is the input list channel a mic comes up on, and Mix is the
+	// monitor mix an IEM transmitter carries.
+	Channel int            `json:"channel,omitempty"`
+	Mix     int            `json:"mix,omitempty"`
+	Tuning  FrequencyRange `json:"tuning"`
+	// Frequency is zero until one has been coordinated.
+	Frequency float64 `json:"frequency,omitempty"`
+}
+
+// Key identifies the device in a show's overrides, by the channel a mic comes
+// up on or the mix an IEM carries. It is empty for a device with neither.
+func (d WirelessDevice) Key() string {
+	switch {
+	case d.Kind == WirelessKindMic && d.Channel > 0:
+		return fmt.Sprintf("mic:%d", d.Channel)
+	case d.Kind == WirelessKindIEM && d.Mix > 0:
+		return fmt.Sprintf("iem:%d", d.Mix)
+	}
+	return ""
+}
+
+type FrequencyRange struct {
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+}
+
+func (r FrequencyRange) kHz() rf.Range {
+	return rf.Range{Low: toKHz(r.Low), High: toKHz(r.High)}
+}
+
+func toKHz(mhz float64) int {
+	return int(math.Round(mhz * 1000))
+}
+
+func toMHz(khz int) float64 {
+	return float64(khz) / 1000
+}
+
+// inBand reports whether the range lies inside the frequencies wireless
+// gear uses. NaN fails every comparison, so it's left out too.
+func (r FrequencyRange) inBand() bool {
+	return r.Low >= minWirelessMHz && r.High <= maxWirelessMHz
+}
+
+func (w *Wireless) validate() error {
+	if len(w.Devices) > maxWirelessDevices {
+		return fmt.Errorf("%w: got %d", ErrWirelessTooMany, len(w.Devices))
+	}
+	for _, device := range w.Devices {
+		if device.Kind != WirelessKindMic && device.Kind != WirelessKindIEM {
+			return fmt.Errorf("%w: got %q", ErrWirelessKindInvalid, device.Kind)
+		} else if !device.Tuning.inBand() {
+			return fmt.Errorf("%w: %s", ErrWirelessBand, device.Name)
+		} else if device.Tuning.Low >= device.Tuning.High {
+			return fmt.Errorf("%w: %s", ErrWirelessRangeInvalid, device.Name)
+		} else if device.Frequency < 0 || math.IsNaN(device.Frequency) {
+			return fmt.Errorf("%w: %s", ErrWirelessFrequencyRange, device.Name)
+		} else if device.Frequency != 0 && !device.Tuning.kHz().Contains(toKHz(device.Frequency)) {
+			return fmt.Errorf("%w: %s", ErrWirelessFrequencyRange, device.Name)
+		}
+	}
+	for _, exclusion := range w.Exclusions {
+		if !exclusion.inBand() {
+			return ErrWirelessBand
+		} else if exclusion.Low >= exclusion.High {
+			return ErrWirelessRangeInvalid
+		}
+	}
+	return nil
+}
+
+func (w *Wireless) settings(extraExclusions []FrequencyRange) rf.Settings {
+	settings := rf.Settings{
+		CarrierSpacing: toKHz(w.CarrierSpacing),
+		IMSpacing:      toKHz(w.IMSpacing),
+	}
+	for _, exclusion := range append(w.Exclusions, extraExclusions...) {
+		settings.Exclusions = append(settings.Exclusions, exclusion.kHz())
+	}
+	return settings
+}
+
+func (w *Wireless) transmitters() []rf.Transmitter {
+	txs := make([]rf.Transmitter, len(w.Devices))
+	for i, device := range w.Devices {
+		txs[i] = rf.Transmitter{
+			Name:      device.Name,
+			Tuning:    device.Tuning.kHz(),
+			Frequency: toKHz(device.Frequency),
+		}
+	}
+	return txs
+}
+
+// Conflicts reports intermodulation and spacing problems between the devices
+// that have a frequency.
+func (w *Wireless) Conflicts() []rf.Conflict {
+	return rf.Check(w.transmitters(), w.settings(nil))
+}
+
+// Coordinate returns a copy of the wireless setup with a frequency for every
+// device that doesn't have one yet, avoiding the rider's exclusions and any
+// extra ones for the venue. Devices that couldn't be fit in are named in the
+// second return value and keep a zero frequency.
+func (w *Wireless) Coordinate(extraExclusions []FrequencyRange) (*Wireless, []string) {
+	planned, unplaced := rf.Plan(w.transmitters(), w.settings(extraExclusions))
+
+	coordinated := *w
+	coordinated.Devices = make([]WirelessDevice, len(w.Devices))
+	for i, device := range w.Devices {
+		device.Frequency = toMHz(planned[i].Frequency)
+		coordinated.Devices[i] = device
+	}
+	coordinated.Exclusions = slices.Clone(w.Exclusions)
+	for _, exclusion := range extraExclusions {
+		if !slices.Contains(coordinated.Exclusions, exclusion) {
+			coordinated.Exclusions = append(coordinated.Exclusions, exclusion)
+		}
+	}
+	return &coordinated, unplaced
+}
+
+func (w *Wireless) section() Section {
+	section := Section{
+		Title:   "Wireless",
+		Headers: []string{"Device", "Type", "Model", "Ch / Mix", "Tuning (MHz)", "Frequency (MHz)"},
+	}
+	if len(w.Exclusions) > 0 {
+		var exclusions []string
+		for _, exclusion := range w.Exclusions {
+			exclusions = append(exclusions, fmt.Sprintf("%.3f-%.3f", exclusion.Low, exclusion.High))
+		}
+		section.Lines = append(section.Lines, fmt.Sprintf("Avoiding: %s MHz", strings.Join(exclusions, ", ")))
+	}
+	for i, device := range w.Devices {
+		routing := ""
+		if device.Channel != 0 {
+			routing = fmt.Sprintf("ch %d", device.Channel)
+		} else if device.Mix != 0 {
+			routing = fmt.Sprintf("mix %d", device.Mix)
+		}
+		frequency := "to be coordinated"
+		if device.Frequency != 0 {
+			frequency = fmt.Sprintf("%.3f", device.Frequency)
+		}
+		section.Rows = append(section.Rows, []string{
+			device.Name,
+			string(device.Kind),
+			device.Model,
+			routing,
+			fmt.Sprintf("%.3f-%.3f", device.Tuning.Low, device.Tuning.High),
+			frequency,
+		})
+		section.RowKeys = append(section.RowKeys, fmt.Sprintf("wireless:%d", i+1))
+	}
+	return section
+}
@@ -26,3 +26,9 @@ returning *;
 -- name: DeleteAccount :exec
 delete from account
 where id = $1;
+
+-- name: UpdateAccountDietaryRestrictions :one
+update account
+  set dietary_restrictions = $2, updated_at = NOW()
+where id = $1
+returning *;
@@ -27,3 +27,19 @@ insert into account_band (
   band_id,
   account_is_admin
 ) values ($1, $2, $3) returning *;
+
+-- name: GetAccountBand :one
+select * from account_band
+where account_id = $1 and band_id = $2
+limit 1;
+
+-- name: GetBandByID :one
+select * from band where id = $1 limit 1;
+
+-- name: GetBandMemberDietaryRestrictions :many
+select a.given_name, a.family_name, a.dietary_restrictions
+from account a
+join account_band ab
+on ab.account_id = a.id and ab.band_id = $1
+where a.dietary_restrictions <> ''
+order by a.given_name, a.family_name;
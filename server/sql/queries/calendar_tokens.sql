@@ -0,0 +1,19 @@
+-- name: UpsertCalendarToken :one
+insert into calendar_token (
+  account_id, token
+) values (
+  $1, $2
+)
+on conflict (account_id) do update
+  set token = excluded.token, created_at = NOW()
+returning *;
+
+-- name: GetCalendarToken :one
+select * from calendar_token
+where account_id = $1
+limit 1;
+
+-- name: GetCalendarTokenByToken :one
+select * from calendar_token
+where token = $1
+limit 1;
@@ -0,0 +1,69 @@
+-- name: CreateGear :one
+insert into gear (
+  band_id,
+  owner_id,
+  name,
+  category,
+  make,
+  model,
+  serial_number,
+  weight_kg,
+  width_cm,
+  depth_cm,
+  height_cm,
+  power_watts,
+  value_cents,
+  currency,
+  notes,
+  case_name,
+  country_of_origin
+) values (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+) returning *;
+
+-- name: GetGear :one
+select * from gear where id = $1 limit 1;
+
+-- name: GetBandGear :many
+select * from gear where band_id = $1 order by category, name, id;
+
+-- name: UpdateGear :one
+update gear
+  set owner_id = $2,
+  name = $3,
+  category = $4,
+  make = $5,
+  model = $6,
+  serial_number = $7,
+  weight_kg = $8,
+  width_cm = $9,
+  depth_cm = $10,
+  height_cm = $11,
+  power_watts = $12,
+  value_cents = $13,
+  currency = $14,
+  notes = $15,
+  case_name = $16,
+  country_of_origin = $17,
+  updated_at = NOW()
+where id = $1
+returning *;
+
+-- name: DeleteGear :exec
+delete from gear where id = $1;
+
+-- name: CreateGearPhoto :one
+insert into gear_photo (gear_id, content_type, data)
+values ($1, $2, $3)
+returning id, gear_id, content_type, created_at;
+
+-- name: GetGearPhotos :many
+select id, gear_id, content_type, created_at from gear_photo
+where gear_id = $1
+order by id;
+
+-- name: GetGearPhoto :one
+select * from gear_photo where id = $1 and gear_id = $2 limit 1;
+
+-- name: DeleteGearPhoto :execrows
+delete from gear_photo where id = $1 and gear_id = $2;
@@ -0,0 +1,13 @@
+-- name: UpsertPackingCheck :one
+insert into packing_check (show_id, item_key, stage, checked_by)
+values ($1, $2, $3, $4)
+on conflict (show_id, item_key, stage) do update
+  set checked_by = excluded.checked_by, checked_at = NOW()
+returning *;
+
+-- name: DeletePackingCheck :execrows
+delete from packing_check
+where show_id = $1 and item_key = $2 and stage = $3;
+
+-- name: GetShowPackingChecks :many
+select * from packing_check where show_id = $1 order by checked_at;
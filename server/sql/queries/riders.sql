@@ -0,0 +1,64 @@
+-- name: CreateRider :one
+insert into rider (band_id, name) values ($1, $2) returning *;
+
+-- name: GetRider :one
+select * from rider where id = $1 limit 1;
+
+-- name: GetBandRiders :many
+select * from rider
+where band_id = $1
+order by id;
+
+-- name: CreateRiderRevision :one
+insert into rider_revision (
+  rider_id,
+  revision,
+  document,
+  author_id
+) values (
+  $1,
+  (select coalesce(max(revision), 0) + 1 from rider_revision where rider_id = $1),
+  $2,
+  $3
+) returning *;
+
+-- name: GetRiderRevisions :many
+select * from rider_revision
+where rider_id = $1
+order by revision;
+
+-- name: GetLatestRiderRevision :one
+select * from rider_revision
+where rider_id = $1
+order by revision desc limit 1;
+
+-- name: GetPublishedRiderRevision :one
+select * from rider_revision
+where rider_id = $1 and status = 'published'
+limit 1;
+
+-- name: UpdateRiderRevisionDocument :one
+update rider_revision
+  set document = $2, updated_at = NOW()
+where id = $1 and status in ('draft', 'in_review')
+returning *;
+
+-- name: SubmitRiderRevision :one
+update rider_revision
+  set status = 'in_review', updated_at = NOW()
+where id = $1 and status = 'draft'
+returning *;
+
+-- name: SupersedeRiderRevisions :exec
+update rider_revision
+  set status = 'superseded', updated_at = NOW()
+where rider_id = $1 and status = 'published';
+
+-- name: PublishRiderRevision :one
+update rider_revision
+  set status = 'published', published_at = NOW(), published_by = $2, updated_at = NOW()
+where id = $1 and status in ('draft', 'in_review')
+returning *;
+
+-- name: GetRiderRevisionByID :one
+select * from rider_revision where id = $1 limit 1;
@@ -0,0 +1,30 @@
+-- name: CreateScheduleItem :one
+insert into schedule_item (
+  show_id,
+  kind,
+  label,
+  starts_at,
+  ends_at,
+  location,
+  notes
+) values (
+  $1, $2, $3, $4, $5, $6, $7
+) returning *;
+
+-- name: GetShowScheduleItems :many
+select * from schedule_item where show_id = $1 order by starts_at, id;
+
+-- name: UpdateScheduleItem :one
+update schedule_item
+  set kind = $3,
+  label = $4,
+  starts_at = $5,
+  ends_at = $6,
+  location = $7,
+  notes = $8,
+  updated_at = NOW()
+where id = $1 and show_id = $2
+returning *;
+
+-- name: DeleteScheduleItem :execrows
+delete from schedule_item where id = $1 and show_id = $2;
@@ -0,0 +1,27 @@
+-- name: CreateShareLink :one
+insert into share_link (
+  rider_id, token, creator_id, expires_at, show_id
+) values (
+  $1, $2, $3, $4, $5
+) returning *;
+
+-- name: GetRiderShareLinks :many
+select * from share_link
+where rider_id = $1
+order by id;
+
+-- name: GetShareLinkByToken :one
+select * from share_link
+where token = $1
+limit 1;
+
+-- name: RevokeShareLink :one
+update share_link
+  set revoked_at = NOW()
+where id = $1 and rider_id = $2 and revoked_at is null
+returning *;
+
+-- name: RecordShareLinkView :exec
+update share_link
+  set view_count = view_count + 1, last_accessed_at = NOW()
+where id = $1;
@@ -0,0 +1,29 @@
+-- name: CreateShareResponse :one
+insert into share_response (
+  share_link_id, revision_id, kind, contact_name, contact_role, line_item, comment
+) values (
+  $1, $2, $3, $4, $5, $6, $7
+) returning *;
+
+-- name: GetShareLinkResponses :many
+select * from share_response
+where share_link_id = $1 and revision_id = $2
+order by created_at;
+
+-- name: GetRiderShareResponses :many
+select
+  sr.id,
+  sr.share_link_id,
+  sr.revision_id,
+  rr.revision,
+  sr.kind,
+  sr.contact_name,
+  sr.contact_role,
+  sr.line_item,
+  sr.comment,
+  sr.created_at
+from share_response sr
+join share_link sl on sl.id = sr.share_link_id
+join rider_revision rr on rr.id = sr.revision_id
+where sl.rider_id = $1
+order by sr.created_at;
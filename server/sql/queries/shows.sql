@@ -0,0 +1,66 @@
+-- name: CreateShow :one
+insert into show (
+  band_id,
+  date,
+  venue_name,
+  city,
+  timezone,
+  load_in_at,
+  soundcheck_at,
+  doors_at,
+  set_at,
+  status,
+  rider_revision_id,
+  venue_id,
+  tour_id,
+  latitude,
+  longitude
+) values (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+) returning *;
+
+-- name: GetShow :one
+select * from show where id = $1 limit 1;
+
+-- name: GetBandShows :many
+select * from show
+where band_id = $1
+  and (sqlc.narg('starts_on')::date is null or date >= sqlc.narg('starts_on'))
+  and (sqlc.narg('ends_on')::date is null or date <= sqlc.narg('ends_on'))
+order by date, id;
+
+-- name: UpdateShow :one
+update show
+  set date = $2,
+  venue_name = $3,
+  city = $4,
+  timezone = $5,
+  load_in_at = $6,
+  soundcheck_at = $7,
+  doors_at = $8,
+  set_at = $9,
+  status = $10,
+  rider_revision_id = $11,
+  venue_id = $12,
+  tour_id = $13,
+  latitude = $14,
+  longitude = $15,
+  sequence = sequence + 1,
+  updated_at = NOW()
+where id = $1
+returning *;
+
+-- name: UpdateShowOverrides :one
+update show
+  set rider_overrides = $2, updated_at = NOW()
+where id = $1
+returning *;
+
+-- name: UpdateShowDaySheetNotes :one
+update show
+  set parking_notes = $2, wifi_notes = $3, day_notes = $4, updated_at = NOW()
+where id = $1
+returning *;
+
+-- name: GetTourShows :many
+select * from show where tour_id = $1 order by date, id;
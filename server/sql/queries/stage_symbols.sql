@@ -0,0 +1,15 @@
+-- name: UpsertStageSymbol :one
+insert into stage_symbol (band_id, item_type, svg)
+values ($1, $2, $3)
+on conflict (band_id, item_type) do update
+  set svg = excluded.svg, updated_at = NOW()
+returning *;
+
+-- name: GetBandStageSymbols :many
+select * from stage_symbol where band_id = $1 order by item_type;
+
+-- name: GetStageSymbol :one
+select * from stage_symbol where band_id = $1 and item_type = $2 limit 1;
+
+-- name: DeleteStageSymbol :execrows
+delete from stage_symbol where band_id = $1 and item_type = $2;
@@ -0,0 +1,50 @@
+-- name: CreateTour :one
+insert into tour (
+  band_id,
+  name,
+  starts_on,
+  ends_on,
+  notes
+) values (
+  $1, $2, $3, $4, $5
+) returning *;
+
+-- name: GetTour :one
+select * from tour where id = $1 limit 1;
+
+-- name: GetBandTours :many
+select * from tour where band_id = $1 order by starts_on, id;
+
+-- name: UpdateTour :one
+update tour
+  set name = $2,
+  starts_on = $3,
+  ends_on = $4,
+  notes = $5,
+  updated_at = NOW()
+where id = $1
+returning *;
+
+-- name: DeleteTour :exec
+delete from tour where id = $1;
+
+-- name: CreateTourPerson :one
+insert into tour_person (
+  tour_id,
+  account_id,
+  name,
+  role
+) values (
+  $1, $2, $3, $4
+) returning *;
+
+-- name: GetTourPeople :many
+select tp.id, tp.tour_id, tp.account_id, tp.role, tp.created_at,
+  coalesce(nullif(trim(a.given_name || ' ' || a.family_name), ''), tp.name)::text as name
+from tour_person tp
+left join account a on a.id = tp.account_id
+where tp.tour_id = $1
+order by tp.account_id is null, tp.id;
+
+-- name: DeleteTourPerson :execrows
+delete from tour_person where id = $1 and tour_id = $2;
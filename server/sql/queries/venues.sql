@@ -0,0 +1,35 @@
+-- name: CreateVenue :one
+insert into venue (
+  band_id,
+  name,
+  city,
+  address,
+  timezone,
+  spec,
+  latitude,
+  longitude
+) values (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) returning *;
+
+-- name: GetVenue :one
+select * from venue where id = $1 limit 1;
+
+-- name: GetBandVenues :many
+select * from venue where band_id = $1 order by name, id;
+
+-- name: UpdateVenue :one
+update venue
+  set name = $2,
+  city = $3,
+  address = $4,
+  timezone = $5,
+  spec = $6,
+  latitude = $7,
+  longitude = $8,
+  updated_at = NOW()
+where id = $1
+returning *;
+
+-- name: DeleteVenue :exec
+delete from venue where id = $1;
@@ -0,0 +1,33 @@
+-- +goose Up
+CREATE TYPE rider_status AS ENUM ('draft', 'in_review', 'published', 'superseded');
+
+CREATE TABLE rider (
+  id serial PRIMARY KEY,
+  band_id int NOT NULL REFERENCES band (id),
+  name text NOT NULL,
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE rider_revision (
+  id serial PRIMARY KEY,
+  rider_id int NOT NULL REFERENCES rider (id),
+  revision int NOT NULL,
+  status rider_status NOT NULL DEFAULT 'draft',
+  document jsonb NOT NULL DEFAULT '{}',
+  author_id int NOT NULL REFERENCES account (id),
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  published_at timestamp,
+  published_by int REFERENCES account (id),
+  UNIQUE (rider_id, revision)
+);
+
+-- a rider only ever has one revision that venues can see
+CREATE UNIQUE INDEX rider_revision_published
+ON rider_revision (rider_id) WHERE status = 'published';
+
+-- +goose Down
+DROP TABLE rider_revision;
+DROP TABLE rider;
+DROP TYPE rider_status;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE share_link (
+  id serial PRIMARY KEY,
+  rider_id int NOT NULL REFERENCES rider (id),
+  token text UNIQUE NOT NULL,
+  creator_id int NOT NULL REFERENCES account (id),
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  expires_at timestamp,
+  revoked_at timestamp,
+  view_count int NOT NULL DEFAULT 0,
+  last_accessed_at timestamp
+);
+
+-- +goose Down
+DROP TABLE share_link;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TYPE share_response_kind AS ENUM ('acknowledged', 'flagged');
+
+CREATE TABLE share_response (
+  id serial PRIMARY KEY,
+  share_link_id int NOT NULL REFERENCES share_link (id),
+  revision_id int NOT NULL REFERENCES rider_revision (id),
+  kind share_response_kind NOT NULL,
+  contact_name text NOT NULL,
+  contact_role text NOT NULL DEFAULT '',
+  line_item text NOT NULL DEFAULT '',
+  comment text NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE share_response;
+DROP TYPE share_response_kind;
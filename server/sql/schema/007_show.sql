@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TYPE show_status AS ENUM ('hold', 'confirmed', 'cancelled');
+
+-- schedule times are wall clock times in the show's timezone, the same way
+-- they're printed on a day sheet
+CREATE TABLE show (
+  id serial PRIMARY KEY,
+  band_id int NOT NULL REFERENCES band (id),
+  date date NOT NULL,
+  venue_name text NOT NULL,
+  city text NOT NULL DEFAULT '',
+  timezone text NOT NULL DEFAULT 'UTC',
+  load_in_at timestamp,
+  soundcheck_at timestamp,
+  doors_at timestamp,
+  set_at timestamp,
+  status show_status NOT NULL DEFAULT 'hold',
+  rider_revision_id int REFERENCES rider_revision (id),
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX show_band_date ON show (band_id, date);
+
+-- +goose Down
+DROP TABLE show;
+DROP TYPE show_status;
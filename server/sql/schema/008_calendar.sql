@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE calendar_token (
+  id serial PRIMARY KEY,
+  account_id int UNIQUE NOT NULL REFERENCES account (id),
+  token text UNIQUE NOT NULL,
+  created_at timestamp NOT NULL DEFAULT NOW()
+);
+
+-- calendar apps only replace an event they already have when its sequence
+-- number goes up
+ALTER TABLE show ADD COLUMN sequence int NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE show DROP COLUMN sequence;
+DROP TABLE calendar_token;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- a JSON merge patch (RFC 7396) layered over the show's rider revision
+ALTER TABLE show ADD COLUMN rider_overrides jsonb NOT NULL DEFAULT '{}';
+
+ALTER TABLE share_link ADD COLUMN show_id int REFERENCES show (id);
+
+-- +goose Down
+ALTER TABLE share_link DROP COLUMN show_id;
+ALTER TABLE show DROP COLUMN rider_overrides;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE account ADD COLUMN dietary_restrictions text NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE account DROP COLUMN dietary_restrictions;
@@ -0,0 +1,36 @@
+-- +goose Up
+-- gear with no owner belongs to the band as a whole
+CREATE TABLE gear (
+  id serial PRIMARY KEY,
+  band_id int NOT NULL REFERENCES band (id),
+  owner_id int REFERENCES account (id),
+  name text NOT NULL,
+  category text NOT NULL DEFAULT '',
+  make text NOT NULL DEFAULT '',
+  model text NOT NULL DEFAULT '',
+  serial_number text NOT NULL DEFAULT '',
+  weight_kg double precision NOT NULL DEFAULT 0,
+  width_cm double precision NOT NULL DEFAULT 0,
+  depth_cm double precision NOT NULL DEFAULT 0,
+  height_cm double precision NOT NULL DEFAULT 0,
+  power_watts int NOT NULL DEFAULT 0,
+  value_cents int NOT NULL DEFAULT 0,
+  currency text NOT NULL DEFAULT 'USD',
+  notes text NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX gear_band ON gear (band_id);
+
+CREATE TABLE gear_photo (
+  id serial PRIMARY KEY,
+  gear_id int NOT NULL REFERENCES gear (id) ON DELETE CASCADE,
+  content_type text NOT NULL,
+  data bytea NOT NULL,
+  created_at timestamp NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE gear_photo;
+DROP TABLE gear;
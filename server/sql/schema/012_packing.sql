@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE gear ADD COLUMN case_name text NOT NULL DEFAULT '';
+
+CREATE TYPE packing_stage AS ENUM ('load_in', 'load_out');
+
+-- item_key names a line of the packing list a show's rider generates, so
+-- checks survive the list being regenerated as long as the line is still on it
+CREATE TABLE packing_check (
+  id serial PRIMARY KEY,
+  show_id int NOT NULL REFERENCES show (id) ON DELETE CASCADE,
+  item_key text NOT NULL,
+  stage packing_stage NOT NULL,
+  checked_by int NOT NULL REFERENCES account (id),
+  checked_at timestamp NOT NULL DEFAULT NOW(),
+  UNIQUE (show_id, item_key, stage)
+);
+
+-- +goose Down
+DROP TABLE packing_check;
+DROP TYPE packing_stage;
+ALTER TABLE gear DROP COLUMN case_name;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE gear ADD COLUMN country_of_origin text NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE gear DROP COLUMN country_of_origin;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- the technical spec is a JSON document, like a rider revision
+CREATE TABLE venue (
+  id serial PRIMARY KEY,
+  band_id int NOT NULL REFERENCES band (id),
+  name text NOT NULL,
+  city text NOT NULL DEFAULT '',
+  address text NOT NULL DEFAULT '',
+  timezone text NOT NULL DEFAULT 'UTC',
+  spec jsonb NOT NULL DEFAULT '{}',
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX venue_band ON venue (band_id);
+
+ALTER TABLE show ADD COLUMN venue_id int REFERENCES venue (id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE show DROP COLUMN venue_id;
+DROP TABLE venue;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- svg is only ever the sanitized form of what was uploaded
+CREATE TABLE stage_symbol (
+  id serial PRIMARY KEY,
+  band_id int NOT NULL REFERENCES band (id),
+  item_type text NOT NULL,
+  svg text NOT NULL,
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  UNIQUE (band_id, item_type)
+);
+
+-- +goose Down
+DROP TABLE stage_symbol;
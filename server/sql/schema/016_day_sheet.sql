@@ -0,0 +1,32 @@
+-- +goose Up
+CREATE TYPE schedule_kind AS ENUM (
+  'travel', 'load_in', 'soundcheck', 'doors', 'support', 'set', 'curfew', 'load_out', 'other'
+);
+
+-- like the show's own times, these are wall clock times in the show's
+-- timezone
+CREATE TABLE schedule_item (
+  id serial PRIMARY KEY,
+  show_id int NOT NULL REFERENCES show (id) ON DELETE CASCADE,
+  kind schedule_kind NOT NULL,
+  label text NOT NULL DEFAULT '',
+  starts_at timestamp NOT NULL,
+  ends_at timestamp,
+  location text NOT NULL DEFAULT '',
+  notes text NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX schedule_item_show ON schedule_item (show_id, starts_at);
+
+ALTER TABLE show ADD COLUMN parking_notes text NOT NULL DEFAULT '';
+ALTER TABLE show ADD COLUMN wifi_notes text NOT NULL DEFAULT '';
+ALTER TABLE show ADD COLUMN day_notes text NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE show DROP COLUMN day_notes;
+ALTER TABLE show DROP COLUMN wifi_notes;
+ALTER TABLE show DROP COLUMN parking_notes;
+DROP TABLE schedule_item;
+DROP TYPE schedule_kind;
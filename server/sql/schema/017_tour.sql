@@ -0,0 +1,45 @@
+-- +goose Up
+CREATE TABLE tour (
+  id serial PRIMARY KEY,
+  band_id int NOT NULL REFERENCES band (id),
+  name text NOT NULL,
+  starts_on date NOT NULL,
+  ends_on date NOT NULL,
+  notes text NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  CHECK (ends_on >= starts_on)
+);
+
+CREATE INDEX tour_band ON tour (band_id, starts_on);
+
+-- band members going out are linked to their accounts, while crew who
+-- don't use the app are only a name
+CREATE TABLE tour_person (
+  id serial PRIMARY KEY,
+  tour_id int NOT NULL REFERENCES tour (id) ON DELETE CASCADE,
+  account_id int REFERENCES account (id) ON DELETE CASCADE,
+  name text NOT NULL DEFAULT '',
+  role text NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  UNIQUE (tour_id, account_id)
+);
+
+ALTER TABLE show ADD COLUMN tour_id int REFERENCES tour (id) ON DELETE SET NULL;
+
+CREATE INDEX show_tour ON show (tour_id, date);
+
+-- coordinates are only used to measure the drive between shows
+ALTER TABLE show ADD COLUMN latitude double precision;
+ALTER TABLE show ADD COLUMN longitude double precision;
+ALTER TABLE venue ADD COLUMN latitude double precision;
+ALTER TABLE venue ADD COLUMN longitude double precision;
+
+-- +goose Down
+ALTER TABLE venue DROP COLUMN longitude;
+ALTER TABLE venue DROP COLUMN latitude;
+ALTER TABLE show DROP COLUMN longitude;
+ALTER TABLE show DROP COLUMN latitude;
+ALTER TABLE show DROP COLUMN tour_id;
+DROP TABLE tour_person;
+DROP TABLE tour;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- stock counts left out used to be saved as 0 and read as one, so they are
+-- written out as one before an explicit 0 starts meaning none available
+UPDATE venue SET spec = jsonb_set(spec, '{mics}', (
+  SELECT jsonb_agg(CASE WHEN (item->>'count')::int = 0 THEN item || '{"count": 1}' ELSE item END)
+  FROM jsonb_array_elements(spec->'mics') item
+))
+WHERE jsonb_typeof(spec->'mics') = 'array' AND jsonb_array_length(spec->'mics') > 0;
+
+UPDATE venue SET spec = jsonb_set(spec, '{backline}', (
+  SELECT jsonb_agg(CASE WHEN (item->>'count')::int = 0 THEN item || '{"count": 1}' ELSE item END)
+  FROM jsonb_array_elements(spec->'backline') item
+))
+WHERE jsonb_typeof(spec->'backline') = 'array' AND jsonb_array_length(spec->'backline') > 0;
+
+-- +goose Down
+-- a count of one reads the same either way, so there is nothing to undo
@@ -0,0 +1,393 @@
+package symbol
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNotSVG           = errors.New("symbols must be SVG images")
+	ErrViewBoxInvalid   = errors.New("symbols need a viewBox or a width and height")
+	ErrLengthInvalid    = errors.New("only plain and px lengths are supported")
+	ErrTransformInvalid = errors.New("invalid transform")
+	ErrTooComplex       = errors.New("symbols can have at most 2000 elements")
+	ErrEmpty            = errors.New("symbols need at least one shape")
+	ErrOutOfRange       = errors.New("symbol coordinates are too large to draw")
+)
+
+const maxElements = 2000
+
+// matrix is an affine transform in SVG's (a b c d e f) order.
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[2]*n[1],
+		m[1]*n[0] + m[3]*n[1],
+		m[0]*n[2] + m[2]*n[3],
+		m[1]*n[2] + m[3]*n[3],
+		m[0]*n[4] + m[2]*n[5] + m[4],
+		m[1]*n[4] + m[3]*n[5] + m[5],
+	}
+}
+
+func (m matrix) apply(x, y float64) (float64, float64) {
+	return m[0]*x + m[2]*y + m[4], m[1]*x + m[3]*y + m[5]
+}
+
+// state is what a shape inherits from the elements around it.
+type state struct {
+	transform matrix
+	fill      bool
+	stroke    bool
+}
+
+// Parse reads an SVG image into the shapes it draws. Only geometry survives:
+// scripts, styles, links, embedded images, text and anything else that isn't
+// a basic shape or a group of them is dropped along with everything inside
+// it, which is what makes uploaded symbols safe to draw.
+func Parse(raw []byte) (Symbol, error) {
+	var sym Symbol
+	dec := xml.NewDecoder(bytes.NewReader(raw))
+	dec.Strict = true
+	// refuse to expand entities, which is how billion laughs gets in
+	dec.Entity = map[string]string{}
+
+	var stack []state
+	skipping := 0
+	elements := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return sym, fmt.Errorf("%w: %v", ErrNotSVG, err)
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			elements++
+			if elements > maxElements {
+				return sym, ErrTooComplex
+			}
+			if skipping > 0 {
+				skipping++
+				continue
+			}
+			attrs := attributes(tok)
+			if len(stack) == 0 {
+				if tok.Name.Local != "svg" {
+					return sym, ErrNotSVG
+				}
+				sym.ViewBox, err = viewBox(attrs)
+				if err != nil {
+					return sym, err
+				}
+				stack = append(stack, state{transform: identity, fill: true})
+				continue
+			}
+
+			parent := stack[len(stack)-1]
+			st, err := inherit(parent, attrs)
+			if err != nil {
+				return sym, err
+			}
+			switch tok.Name.Local {
+			case "g":
+				stack = append(stack, st)
+				continue
+			case "path", "rect", "circle", "ellipse", "line", "polyline", "polygon":
+				segs, err := shape(tok.Name.Local, attrs)
+				if err != nil {
+					return sym, fmt.Errorf("%s: %w", tok.Name.Local, err)
+				}
+				if len(segs) > 0 && (st.fill || st.stroke) {
+					path := transformed(Path{Segments: segs, Fill: st.fill, Stroke: st.stroke}, st.transform)
+					if !path.finite() {
+						return sym, fmt.Errorf("%s: %w", tok.Name.Local, ErrOutOfRange)
+					}
+					sym.Paths = append(sym.Paths, path)
+				}
+			}
+			// shapes have no children worth drawing, and anything else is
+			// dropped whole
+			skipping = 1
+		case xml.EndElement:
+			if skipping > 0 {
+				skipping--
+			} else if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	if len(sym.Paths) == 0 {
+		return sym, ErrEmpty
+	}
+	return sym, nil
+}
+
+func attributes(el xml.StartElement) map[string]string {
+	attrs := make(map[string]string, len(el.Attr))
+	for _, attr := range el.Attr {
+		// namespaced attributes like xlink:href are never wanted
+		if attr.Name.Space == "" {
+			attrs[attr.Name.Local] = strings.TrimSpace(attr.Value)
+		}
+	}
+	// the presentation attributes used here can also be set in a style
+	for _, decl := range strings.Split(attrs["style"], ";") {
+		name, value, ok := strings.Cut(decl, ":")
+		name = strings.TrimSpace(name)
+		if ok && (name == "fill" || name == "stroke") {
+			attrs[name] = strings.TrimSpace(value)
+		}
+	}
+	return attrs
+}
+
+func viewBox(attrs map[string]string) ([4]float64, error) {
+	if raw, ok := attrs["viewBox"]; ok {
+		nums, err := numbers(raw)
+		if err != nil || len(nums) != 4 || nums[2] <= 0 || nums[3] <= 0 {
+			return [4]float64{}, ErrViewBoxInvalid
+		}
+		return [4]float64(nums), nil
+	}
+	w, err := length(attrs["width"])
+	if err != nil || w <= 0 {
+		return [4]float64{}, ErrViewBoxInvalid
+	}
+	h, err := length(attrs["height"])
+	if err != nil || h <= 0 {
+		return [4]float64{}, ErrViewBoxInvalid
+	}
+	return [4]float64{0, 0, w, h}, nil
+}
+
+func inherit(parent state, attrs map[string]string) (state, error) {
+	st := parent
+	if fill, ok := attrs["fill"]; ok && fill != "inherit" {
+		st.fill = fill != "none" && fill != "transparent"
+	}
+	if stroke, ok := attrs["stroke"]; ok && stroke != "inherit" {
+		st.stroke = stroke != "none" && stroke != "transparent"
+	}
+	if raw, ok := attrs["transform"]; ok {
+		m, err := parseTransform(raw)
+		if err != nil {
+			return st, err
+		}
+		st.transform = parent.transform.mul(m)
+	}
+	return st, nil
+}
+
+func parseTransform(raw string) (matrix, error) {
+	m := identity
+	rest := strings.TrimSpace(raw)
+	for rest != "" {
+		name, after, ok := strings.Cut(rest, "(")
+		if !ok {
+			return m, ErrTransformInvalid
+		}
+		args, after, ok := strings.Cut(after, ")")
+		if !ok {
+			return m, ErrTransformInvalid
+		}
+		rest = strings.TrimLeft(after, " ,\t\n")
+		v, err := numbers(args)
+		if err != nil {
+			return m, ErrTransformInvalid
+		}
+
+		var t matrix
+		switch strings.TrimSpace(name) {
+		case "matrix":
+			if len(v) != 6 {
+				return m, ErrTransformInvalid
+			}
+			t = matrix(v)
+		case "translate":
+			if len(v) == 1 {
+				v = append(v, 0)
+			} else if len(v) != 2 {
+				return m, ErrTransformInvalid
+			}
+			t = matrix{1, 0, 0, 1, v[0], v[1]}
+		case "scale":
+			if len(v) == 1 {
+				v = append(v, v[0])
+			} else if len(v) != 2 {
+				return m, ErrTransformInvalid
+			}
+			t = matrix{v[0], 0, 0, v[1], 0, 0}
+		case "rotate":
+			if len(v) != 1 && len(v) != 3 {
+				return m, ErrTransformInvalid
+			}
+			a := v[0] * math.Pi / 180
+			t = matrix{math.Cos(a), math.Sin(a), -math.Sin(a), math.Cos(a), 0, 0}
+			if len(v) == 3 {
+				t = matrix{1, 0, 0, 1, v[1], v[2]}.mul(t).mul(matrix{1, 0, 0, 1, -v[1], -v[2]})
+			}
+		case "skewX":
+			if len(v) != 1 {
+				return m, ErrTransformInvalid
+			}
+			t = matrix{1, 0, math.Tan(v[0] * math.Pi / 180), 1, 0, 0}
+		case "skewY":
+			if len(v) != 1 {
+				return m, ErrTransformInvalid
+			}
+			t = matrix{1, math.Tan(v[0] * math.Pi / 180), 0, 1, 0, 0}
+		default:
+			return m, ErrTransformInvalid
+		}
+		m = m.mul(t)
+	}
+	return m, nil
+}
+
+// shape converts a basic shape element to path segments.
+func shape(name string, attrs map[string]string) ([]Segment, error) {
+	get := func(names ...string) ([]float64, error) {
+		v := make([]float64, len(names))
+		for i, name := range names {
+			var err error
+			v[i], err = length(attrs[name])
+			if err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	}
+
+	switch name {
+	case "path":
+		return parsePath(attrs["d"])
+	case "rect":
+		v, err := get("x", "y", "width", "height")
+		if err != nil || v[2] <= 0 || v[3] <= 0 {
+			return nil, err
+		}
+		x, y, w, h := v[0], v[1], v[2], v[3]
+		return []Segment{
+			{Op: 'M', Points: []float64{x, y}},
+			{Op: 'L', Points: []float64{x + w, y}},
+			{Op: 'L', Points: []float64{x + w, y + h}},
+			{Op: 'L', Points: []float64{x, y + h}},
+			{Op: 'Z'},
+		}, nil
+	case "circle":
+		v, err := get("cx", "cy", "r")
+		if err != nil {
+			return nil, err
+		}
+		return ellipse(v[0], v[1], v[2], v[2]), nil
+	case "ellipse":
+		v, err := get("cx", "cy", "rx", "ry")
+		if err != nil {
+			return nil, err
+		}
+		return ellipse(v[0], v[1], v[2], v[3]), nil
+	case "line":
+		v, err := get("x1", "y1", "x2", "y2")
+		if err != nil {
+			return nil, err
+		}
+		return []Segment{
+			{Op: 'M', Points: []float64{v[0], v[1]}},
+			{Op: 'L', Points: []float64{v[2], v[3]}},
+		}, nil
+	case "polyline", "polygon":
+		v, err := numbers(attrs["points"])
+		if err != nil || len(v) < 4 {
+			return nil, err
+		}
+		segs := []Segment{{Op: 'M', Points: []float64{v[0], v[1]}}}
+		for i := 2; i+1 < len(v); i += 2 {
+			segs = append(segs, Segment{Op: 'L', Points: []float64{v[i], v[i+1]}})
+		}
+		if name == "polygon" {
+			segs = append(segs, Segment{Op: 'Z'})
+		}
+		return segs, nil
+	}
+	return nil, nil
+}
+
+func ellipse(cx, cy, rx, ry float64) []Segment {
+	if rx <= 0 || ry <= 0 {
+		return nil
+	}
+	// the usual four curve approximation of a quarter ellipse
+	const k = 0.5522847498
+	return []Segment{
+		{Op: 'M', Points: []float64{cx + rx, cy}},
+		{Op: 'C', Points: []float64{cx + rx, cy + k*ry, cx + k*rx, cy + ry, cx, cy + ry}},
+		{Op: 'C', Points: []float64{cx - k*rx, cy + ry, cx - rx, cy + k*ry, cx - rx, cy}},
+		{Op: 'C', Points: []float64{cx - rx, cy - k*ry, cx - k*rx, cy - ry, cx, cy - ry}},
+		{Op: 'C', Points: []float64{cx + k*rx, cy - ry, cx + rx, cy - k*ry, cx + rx, cy}},
+		{Op: 'Z'},
+	}
+}
+
+func transformed(p Path, m matrix) Path {
+	if m == identity {
+		return p
+	}
+	for i, seg := range p.Segments {
+		points := make([]float64, len(seg.Points))
+		for j := 0; j+1 < len(points); j += 2 {
+			points[j], points[j+1] = m.apply(seg.Points[j], seg.Points[j+1])
+		}
+		p.Segments[i].Points = points
+	}
+	return p
+}
+
+// finite reports whether every point is a real number, which a large enough
+// transform can break even when the path data is fine.
+func (p Path) finite() bool {
+	for _, seg := range p.Segments {
+		for _, f := range seg.Points {
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func length(raw string) (float64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(strings.TrimSuffix(raw, "px"), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%w: got %q", ErrLengthInvalid, raw)
+	}
+	return f, nil
+}
+
+func numbers(raw string) ([]float64, error) {
+	p := &pathScanner{s: raw}
+	var v []float64
+	for !p.done() {
+		n, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		v = append(v, n)
+	}
+	return v, nil
+}
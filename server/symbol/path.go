@@ -0,0 +1,349 @@
+package symbol
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+var ErrPathInvalid = errors.New("invalid path data")
+
+// pathScanner reads the numbers and commands of SVG path data, which can be
+// written with hardly any separators, e.g. "M1.5.5l-1e-3,2".
+type pathScanner struct {
+	s string
+	i int
+}
+
+func (p *pathScanner) skip() {
+	for p.i < len(p.s) && (p.s[p.i] == ' ' || p.s[p.i] == ',' || p.s[p.i] == '\t' || p.s[p.i] == '\n' || p.s[p.i] == '\r') {
+		p.i++
+	}
+}
+
+func (p *pathScanner) done() bool {
+	p.skip()
+	return p.i >= len(p.s)
+}
+
+func (p *pathScanner) command() (byte, bool) {
+	p.skip()
+	if p.i < len(p.s) && isCommand(p.s[p.i]) {
+		p.i++
+		return p.s[p.i-1], true
+	}
+	return 0, false
+}
+
+func (p *pathScanner) number() (float64, error) {
+	p.skip()
+	start := p.i
+	if p.i < len(p.s) && (p.s[p.i] == '-' || p.s[p.i] == '+') {
+		p.i++
+	}
+	dot, digits := false, false
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		if c >= '0' && c <= '9' {
+			digits = true
+		} else if c == '.' && !dot {
+			dot = true
+		} else {
+			break
+		}
+		p.i++
+	}
+	if digits && p.i < len(p.s) && (p.s[p.i] == 'e' || p.s[p.i] == 'E') {
+		j := p.i + 1
+		if j < len(p.s) && (p.s[j] == '-' || p.s[j] == '+') {
+			j++
+		}
+		if j < len(p.s) && p.s[j] >= '0' && p.s[j] <= '9' {
+			p.i = j
+			for p.i < len(p.s) && p.s[p.i] >= '0' && p.s[p.i] <= '9' {
+				p.i++
+			}
+		}
+	}
+	if !digits {
+		return 0, fmt.Errorf("%w: expected a number at %d", ErrPathInvalid, start)
+	}
+	return strconv.ParseFloat(p.s[start:p.i], 64)
+}
+
+// flag reads an arc flag, which is a single 0 or 1 that can be run into the
+// next number.
+func (p *pathScanner) flag() (bool, error) {
+	p.skip()
+	if p.i < len(p.s) && (p.s[p.i] == '0' || p.s[p.i] == '1') {
+		p.i++
+		return p.s[p.i-1] == '1', nil
+	}
+	return false, fmt.Errorf("%w: expected an arc flag at %d", ErrPathInvalid, p.i)
+}
+
+func isCommand(c byte) bool {
+	switch c | 0x20 {
+	case 'm', 'z', 'l', 'h', 'v', 'c', 's', 'q', 't', 'a':
+		return true
+	}
+	return false
+}
+
+// parsePath converts path data to absolute moves, lines and cubic curves.
+func parsePath(d string) ([]Segment, error) {
+	p := &pathScanner{s: d}
+	var segs []Segment
+	var cx, cy, sx, sy float64
+	// the last control point, for the smooth curve commands
+	var lastCmd byte
+	var qx, qy, kx, ky float64
+
+	cmd, ok := p.command()
+	if !ok {
+		if p.done() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: must start with a command", ErrPathInvalid)
+	}
+	for {
+		rel := cmd >= 'a'
+		abs := func(x, y float64) (float64, float64) {
+			if rel {
+				return cx + x, cy + y
+			}
+			return x, y
+		}
+		nums := func(n int) ([]float64, error) {
+			v := make([]float64, n)
+			for i := range v {
+				var err error
+				v[i], err = p.number()
+				if err != nil {
+					return nil, err
+				}
+			}
+			return v, nil
+		}
+
+		switch cmd | 0x20 {
+		case 'z':
+			segs = append(segs, Segment{Op: 'Z'})
+			cx, cy = sx, sy
+		case 'm', 'l':
+			v, err := nums(2)
+			if err != nil {
+				return nil, err
+			}
+			x, y := abs(v[0], v[1])
+			op := byte('L')
+			if cmd|0x20 == 'm' {
+				op = 'M'
+				sx, sy = x, y
+				// further pairs after a move are lines
+				if rel {
+					cmd = 'l'
+				} else {
+					cmd = 'L'
+				}
+			}
+			segs = append(segs, Segment{Op: op, Points: []float64{x, y}})
+			cx, cy = x, y
+		case 'h':
+			v, err := nums(1)
+			if err != nil {
+				return nil, err
+			}
+			x := v[0]
+			if rel {
+				x += cx
+			}
+			segs = append(segs, Segment{Op: 'L', Points: []float64{x, cy}})
+			cx = x
+		case 'v':
+			v, err := nums(1)
+			if err != nil {
+				return nil, err
+			}
+			y := v[0]
+			if rel {
+				y += cy
+			}
+			segs = append(segs, Segment{Op: 'L', Points: []float64{cx, y}})
+			cy = y
+		case 'c', 's':
+			var x1, y1 float64
+			if cmd|0x20 == 's' {
+				x1, y1 = cx, cy
+				if lastCmd == 'c' || lastCmd == 's' {
+					x1, y1 = 2*cx-kx, 2*cy-ky
+				}
+			} else {
+				v, err := nums(2)
+				if err != nil {
+					return nil, err
+				}
+				x1, y1 = abs(v[0], v[1])
+			}
+			v, err := nums(4)
+			if err != nil {
+				return nil, err
+			}
+			x2, y2 := abs(v[0], v[1])
+			x, y := abs(v[2], v[3])
+			segs = append(segs, Segment{Op: 'C', Points: []float64{x1, y1, x2, y2, x, y}})
+			kx, ky = x2, y2
+			cx, cy = x, y
+		case 'q', 't':
+			var x1, y1 float64
+			if cmd|0x20 == 't' {
+				x1, y1 = cx, cy
+				if lastCmd == 'q' || lastCmd == 't' {
+					x1, y1 = 2*cx-qx, 2*cy-qy
+				}
+			} else {
+				v, err := nums(2)
+				if err != nil {
+					return nil, err
+				}
+				x1, y1 = abs(v[0], v[1])
+			}
+			v, err := nums(2)
+			if err != nil {
+				return nil, err
+			}
+			x, y := abs(v[0], v[1])
+			segs = append(segs, quadratic(cx, cy, x1, y1, x, y))
+			qx, qy = x1, y1
+			cx, cy = x, y
+		case 'a':
+			v, err := nums(3)
+			if err != nil {
+				return nil, err
+			}
+			large, err := p.flag()
+			if err != nil {
+				return nil, err
+			}
+			sweep, err := p.flag()
+			if err != nil {
+				return nil, err
+			}
+			end, err := nums(2)
+			if err != nil {
+				return nil, err
+			}
+			x, y := abs(end[0], end[1])
+			curves, err := arc(cx, cy, v[0], v[1], v[2], large, sweep, x, y)
+			if err != nil {
+				return nil, err
+			}
+			segs = append(segs, curves...)
+			cx, cy = x, y
+		}
+		lastCmd = cmd | 0x20
+
+		if p.done() {
+			return segs, nil
+		} else if next, ok := p.command(); ok {
+			cmd = next
+		} else if cmd|0x20 == 'z' {
+			return nil, fmt.Errorf("%w: unexpected number after close", ErrPathInvalid)
+		}
+		// otherwise the command repeats with the next set of numbers
+	}
+}
+
+func quadratic(x0, y0, x1, y1, x, y float64) Segment {
+	return Segment{Op: 'C', Points: []float64{
+		x0 + 2.0/3*(x1-x0), y0 + 2.0/3*(y1-y0),
+		x + 2.0/3*(x1-x), y + 2.0/3*(y1-y),
+		x, y,
+	}}
+}
+
+// arc converts an SVG elliptical arc to cubic curves of at most a quarter
+// turn each, following the endpoint to center conversion in the SVG spec.
+func arc(x1, y1, rx, ry, angle float64, large, sweep bool, x2, y2 float64) ([]Segment, error) {
+	if x1 == x2 && y1 == y2 {
+		return nil, nil
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		return []Segment{{Op: 'L', Points: []float64{x2, y2}}}, nil
+	}
+
+	phi := angle * math.Pi / 180
+	cos, sin := math.Cos(phi), math.Sin(phi)
+	dx, dy := (x1-x2)/2, (y1-y2)/2
+	x1p := cos*dx + sin*dy
+	y1p := -sin*dx + cos*dy
+
+	// scale up radii that are too small to reach the end point
+	if l := x1p*x1p/(rx*rx) + y1p*y1p/(ry*ry); l > 1 {
+		rx *= math.Sqrt(l)
+		ry *= math.Sqrt(l)
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1p*y1p - ry*ry*x1p*x1p
+	den := rx*rx*y1p*y1p + ry*ry*x1p*x1p
+	coef := math.Sqrt(math.Max(num, 0) / den)
+	if large == sweep {
+		coef = -coef
+	}
+	cxp := coef * rx * y1p / ry
+	cyp := -coef * ry * x1p / rx
+	cx := cos*cxp - sin*cyp + (x1+x2)/2
+	cy := sin*cxp + cos*cyp + (y1+y2)/2
+
+	vecAngle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta := vecAngle(1, 0, (x1p-cxp)/rx, (y1p-cyp)/ry)
+	delta := vecAngle((x1p-cxp)/rx, (y1p-cyp)/ry, (-x1p-cxp)/rx, (-y1p-cyp)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	point := func(t float64) (float64, float64) {
+		x, y := rx*math.Cos(t), ry*math.Sin(t)
+		return cos*x - sin*y + cx, sin*x + cos*y + cy
+	}
+	deriv := func(t float64) (float64, float64) {
+		x, y := -rx*math.Sin(t), ry*math.Cos(t)
+		return cos*x - sin*y, sin*x + cos*y
+	}
+
+	// radii big enough to overflow leave nothing to draw with
+	for _, f := range []float64{cx, cy, theta, delta} {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("%w: arc radii are too large", ErrPathInvalid)
+		}
+	}
+
+	// a finite delta is at most a full turn, so four curves at most
+	n := min(max(int(math.Ceil(math.Abs(delta)/(math.Pi/2))), 1), 4)
+	step := delta / float64(n)
+	k := 4.0 / 3 * math.Tan(step/4)
+	segs := make([]Segment, 0, n)
+	for i := range n {
+		t0 := theta + float64(i)*step
+		t1 := t0 + step
+		px0, py0 := point(t0)
+		px1, py1 := point(t1)
+		dx0, dy0 := deriv(t0)
+		dx1, dy1 := deriv(t1)
+		segs = append(segs, Segment{Op: 'C', Points: []float64{
+			px0 + k*dx0, py0 + k*dy0,
+			px1 - k*dx1, py1 - k*dy1,
+			px1, py1,
+		}})
+	}
+	// land exactly on the end point whatever the rounding
+	last := segs[len(segs)-1].Points
+	last[4], last[5] = x2, y2
+	return segs, nil
+}
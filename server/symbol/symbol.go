@@ -0,0 +1,192 @@
+// Package symbol draws stage plot items as proper symbols rather than
+// rectangles. Symbols are SVG images reduced to plain geometry, so a band's
+// own uploads can be drawn alongside the built in library without trusting
+// anything else in the file.
+package symbol
+
+import (
+	"embed"
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Segment is one step of a path in absolute coordinates: a move (M) or line
+// (L) to one point, a cubic curve (C) through two control points to a third,
+// or a close (Z).
+type Segment struct {
+	Op     byte
+	Points []float64
+}
+
+type Path struct {
+	Segments []Segment
+	Fill     bool
+	Stroke   bool
+}
+
+// D writes the path as SVG path data.
+func (p Path) D() string {
+	var b strings.Builder
+	for _, seg := range p.Segments {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteByte(seg.Op)
+		for _, f := range seg.Points {
+			b.WriteByte(' ')
+			b.WriteString(formatFloat(f))
+		}
+	}
+	return b.String()
+}
+
+// formatFloat keeps a thousandth of a unit, which is finer than any symbol
+// is drawn at.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(math.Round(f*1000)/1000, 'f', -1, 64)
+}
+
+// Class is the CSS class the renderer styles the path with.
+func (p Path) Class() string {
+	switch {
+	case p.Fill && p.Stroke:
+		return "fill stroke"
+	case p.Fill:
+		return "fill"
+	}
+	return "stroke"
+}
+
+type Symbol struct {
+	// ViewBox is the min x, min y, width and height of the drawing.
+	ViewBox [4]float64
+	Paths   []Path
+}
+
+func (s Symbol) ViewBoxString() string {
+	parts := make([]string, 4)
+	for i, f := range s.ViewBox {
+		parts[i] = formatFloat(f)
+	}
+	return strings.Join(parts, " ")
+}
+
+// SVG writes the symbol back out as a standalone image. This is the only form
+// an uploaded symbol is ever stored or served in.
+func (s Symbol) SVG() []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="%s">`, s.ViewBoxString())
+	for _, p := range s.Paths {
+		fill, stroke := "none", "none"
+		if p.Fill {
+			fill = "#ccc"
+		}
+		if p.Stroke {
+			stroke = "#111"
+		}
+		fmt.Fprintf(&b, `<path d="%s" fill="%s" stroke="%s" vector-effect="non-scaling-stroke"/>`, p.D(), fill, stroke)
+	}
+	b.WriteString("</svg>")
+	return []byte(b.String())
+}
+
+// Fit scales and moves the symbol's paths into a width by height box,
+// keeping its proportions and centering it.
+func (s Symbol) Fit(width, height float64) []Path {
+	vx, vy, vw, vh := s.ViewBox[0], s.ViewBox[1], s.ViewBox[2], s.ViewBox[3]
+	scale := min(width/vw, height/vh)
+	m := matrix{scale, 0, 0, scale, (width-vw*scale)/2 - vx*scale, (height-vh*scale)/2 - vy*scale}
+
+	paths := make([]Path, len(s.Paths))
+	for i, p := range s.Paths {
+		p.Segments = append([]Segment(nil), p.Segments...)
+		paths[i] = transformed(p, m)
+	}
+	return paths
+}
+
+//go:embed library/*.svg
+var libraryFS embed.FS
+
+// Library is the built in symbols, keyed by stage plot item type.
+var Library = Set{}
+
+// aliases map other common item types onto the library's.
+var aliases = map[string]string{
+	"drums":      "drum-riser",
+	"drum-kit":   "drum-riser",
+	"kit":        "drum-riser",
+	"riser":      "drum-riser",
+	"monitor":    "wedge",
+	"amplifier":  "amp",
+	"guitar-amp": "amp",
+	"bass-amp":   "amp",
+	"cab":        "amp",
+	"keyboard":   "keyboard-stand",
+	"keys":       "keyboard-stand",
+	"di-box":     "di",
+	"drop":       "power-drop",
+	"power":      "power-drop",
+	"mic":        "mic-stand",
+	"microphone": "mic-stand",
+	"vocal-mic":  "mic-stand",
+}
+
+func init() {
+	files, err := libraryFS.ReadDir("library")
+	if err != nil {
+		panic("symbol: failed to read library: " + err.Error())
+	}
+	for _, file := range files {
+		raw, err := libraryFS.ReadFile("library/" + file.Name())
+		if err != nil {
+			panic("symbol: failed to read library: " + err.Error())
+		}
+		sym, err := Parse(raw)
+		if err != nil {
+			panic(fmt.Sprintf("symbol: failed to parse %s: %v", file.Name(), err))
+		}
+		Library[strings.TrimSuffix(file.Name(), path.Ext(file.Name()))] = sym
+	}
+}
+
+// Set is a collection of symbols keyed by item type. A band's custom symbols
+// are kept in a set of their own, which falls back to the library.
+type Set map[string]Symbol
+
+// For finds the symbol to draw an item type with, or nil if there isn't one
+// and it should be drawn as a plain box.
+func (s Set) For(kind string) *Symbol {
+	key := Key(kind)
+	if sym, ok := s[key]; ok {
+		return &sym
+	}
+	if alias, ok := aliases[key]; ok {
+		key = alias
+	}
+	if sym, ok := s[key]; ok {
+		return &sym
+	} else if sym, ok := Library[key]; ok {
+		return &sym
+	}
+	return nil
+}
+
+var keyPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Key normalizes an item type, so "Drum Riser" and "drum_riser" share a
+// symbol.
+func Key(kind string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(kind), func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	}), "-")
+}
+
+// ValidKey reports whether a normalized key can name a custom symbol.
+func ValidKey(key string) bool {
+	return len(key) <= 40 && keyPattern.MatchString(key)
+}
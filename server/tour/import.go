@@ -0,0 +1,285 @@
+package tour
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ShowColumn is a field of a show that an imported column can fill.
+type ShowColumn string
+
+const (
+	ColumnDate      ShowColumn = "date"
+	ColumnVenue     ShowColumn = "venue"
+	ColumnCity      ShowColumn = "city"
+	ColumnTimezone  ShowColumn = "timezone"
+	ColumnLatitude  ShowColumn = "latitude"
+	ColumnLongitude ShowColumn = "longitude"
+	ColumnStatus    ShowColumn = "status"
+)
+
+// headerNames are the column titles we expect on a routing spreadsheet,
+// normalized by normalizeHeader.
+var headerNames = map[string]ShowColumn{
+	"date":      ColumnDate,
+	"day":       ColumnDate,
+	"showdate":  ColumnDate,
+	"venue":     ColumnVenue,
+	"venuename": ColumnVenue,
+	"room":      ColumnVenue,
+	"club":      ColumnVenue,
+	"city":      ColumnCity,
+	"town":      ColumnCity,
+	"market":    ColumnCity,
+	"location":  ColumnCity,
+	"timezone":  ColumnTimezone,
+	"tz":        ColumnTimezone,
+	"lat":       ColumnLatitude,
+	"latitude":  ColumnLatitude,
+	"lng":       ColumnLongitude,
+	"lon":       ColumnLongitude,
+	"long":      ColumnLongitude,
+	"longitude": ColumnLongitude,
+	"status":    ColumnStatus,
+	"hold":      ColumnStatus,
+	"confirmed": ColumnStatus,
+}
+
+// positionalLayout is assumed when a sheet has no header row we recognize.
+var positionalLayout = []ShowColumn{ColumnDate, ColumnVenue, ColumnCity}
+
+// headerSearchRows is how far down a sheet we look for the header row, past
+// any tour name printed above it.
+const headerSearchRows = 10
+
+// dateLayouts are the date formats we accept, besides the serial numbers a
+// spreadsheet stores dates as. Numeric day/month orders are left out since
+// they can't be told apart.
+var dateLayouts = []string{
+	DateLayout,
+	"2006/01/02",
+	"2 Jan 2006",
+	"Mon 2 Jan 2006",
+	"Jan 2 2006",
+	"Jan 2, 2006",
+	"Mon Jan 2, 2006",
+	"January 2, 2006",
+	"2 January 2006",
+}
+
+// spreadsheetEpoch is day zero for spreadsheet date serials, allowing for
+// the 1900 leap year bug they all copy.
+var spreadsheetEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// minSerial and maxSerial are the date serials for 1950-01-01 and
+// 9999-12-31. Smaller numbers are more likely a bare year or a typo than a
+// date, and larger ones aren't dates at all.
+const (
+	minSerial = 18264
+	maxSerial = 2958465
+)
+
+func normalizeHeader(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.TrimSuffix(s, ".")
+	return strings.NewReplacer(" ", "", "_", "", "-", "", "/", "").Replace(s)
+}
+
+// ImportError is a problem with one row of an imported routing. Rows are
+// numbered from one, the way a spreadsheet shows them.
+type ImportError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ImportedShow is a show read from a routing spreadsheet. Blank timezones and
+// statuses are left for the show defaults to fill.
+type ImportedShow struct {
+	Row      int       `json:"row"`
+	Date     time.Time `json:"-"`
+	Venue    string    `json:"venue"`
+	City     string    `json:"city"`
+	Timezone string    `json:"timezone"`
+	Location *Point    `json:"location"`
+	Status   string    `json:"status"`
+}
+
+type ShowImport struct {
+	// Layout maps each recognized column, by position, to the show field
+	// it fills.
+	Layout    map[int]ShowColumn `json:"layout"`
+	HeaderRow int                `json:"header_row,omitempty"`
+	Shows     []ImportedShow     `json:"shows"`
+	Errors    []ImportError      `json:"errors"`
+}
+
+// ImportShows reads shows from the rows of a routing spreadsheet. Like an
+// input list import, every row with a problem is reported rather than
+// stopping at the first one.
+func ImportShows(rows [][]string) ShowImport {
+	result := ShowImport{Shows: []ImportedShow{}, Errors: []ImportError{}}
+	result.Layout, result.HeaderRow = detectLayout(rows)
+
+	for i := result.HeaderRow; i < len(rows); i++ {
+		row := rows[i]
+		if blankRow(row) {
+			continue
+		}
+
+		show := ImportedShow{Row: i + 1}
+		var problems []string
+		var lat, lng string
+		for column, field := range result.Layout {
+			if column >= len(row) {
+				continue
+			}
+			value := strings.TrimSpace(row[column])
+			switch field {
+			case ColumnDate:
+				date, err := parseDate(value)
+				if err != nil {
+					problems = append(problems, err.Error())
+				}
+				show.Date = date
+			case ColumnVenue:
+				show.Venue = value
+			case ColumnCity:
+				show.City = value
+			case ColumnTimezone:
+				show.Timezone = value
+			case ColumnLatitude:
+				lat = value
+			case ColumnLongitude:
+				lng = value
+			case ColumnStatus:
+				status, ok := parseStatus(value)
+				if !ok {
+					problems = append(problems, fmt.Sprintf("unknown status %q", value))
+				}
+				show.Status = status
+			}
+		}
+
+		if show.Date.IsZero() && len(problems) == 0 {
+			problems = append(problems, "missing a date")
+		}
+		if show.Venue == "" {
+			problems = append(problems, "missing a venue")
+		}
+		if show.Timezone != "" {
+			if _, err := time.LoadLocation(show.Timezone); err != nil {
+				problems = append(problems, fmt.Sprintf("unknown timezone %q", show.Timezone))
+			}
+		}
+		location, err := parsePoint(lat, lng)
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+		show.Location = location
+
+		if len(problems) > 0 {
+			result.Errors = append(result.Errors, ImportError{Row: i + 1, Message: strings.Join(problems, ", ")})
+			continue
+		}
+		result.Shows = append(result.Shows, show)
+	}
+	return result
+}
+
+// detectLayout finds the header row and maps its columns, falling back to
+// date, venue and city when there's no header. The returned row is one based,
+// or zero when no header was found.
+func detectLayout(rows [][]string) (map[int]ShowColumn, int) {
+	for i := 0; i < len(rows) && i < headerSearchRows; i++ {
+		layout := map[int]ShowColumn{}
+		used := map[ShowColumn]bool{}
+		for column, cell := range rows[i] {
+			field, ok := headerNames[normalizeHeader(cell)]
+			if !ok || used[field] {
+				continue
+			}
+			layout[column] = field
+			used[field] = true
+		}
+		if used[ColumnDate] && used[ColumnVenue] {
+			return layout, i + 1
+		}
+	}
+
+	layout := make(map[int]ShowColumn, len(positionalLayout))
+	for column, field := range positionalLayout {
+		layout[column] = field
+	}
+	return layout, 0
+}
+
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, errors.New("missing a date")
+	}
+	if serial, err := strconv.ParseFloat(s, 64); err == nil {
+		// written this way round so NaN is turned away too
+		if !(serial >= minSerial && serial <= maxSerial) {
+			return time.Time{}, fmt.Errorf("can't read the date %q, use %s", s, DateLayout)
+		}
+		return spreadsheetEpoch.AddDate(0, 0, int(serial)), nil
+	}
+	for _, layout := range dateLayouts {
+		date, err := time.Parse(layout, s)
+		if err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("can't read the date %q, use %s", s, DateLayout)
+}
+
+func parseStatus(s string) (string, bool) {
+	switch strings.ToLower(s) {
+	case "":
+		return "", true
+	case "hold", "held", "on hold", "pencil", "penciled", "pencilled":
+		return "hold", true
+	case "confirmed", "confirm", "booked", "yes", "y", "x":
+		return "confirmed", true
+	case "cancelled", "canceled", "cxl", "off":
+		return "cancelled", true
+	}
+	return "", false
+}
+
+// parsePoint reads a latitude and longitude, which are optional but have to
+// come as a pair.
+func parsePoint(lat, lng string) (*Point, error) {
+	if lat == "" && lng == "" {
+		return nil, nil
+	} else if lat == "" || lng == "" {
+		return nil, errors.New("latitude and longitude have to be given together")
+	}
+
+	var p Point
+	var err error
+	p.Latitude, err = strconv.ParseFloat(lat, 64)
+	if err != nil {
+		return nil, fmt.Errorf("can't read the latitude %q", lat)
+	}
+	p.Longitude, err = strconv.ParseFloat(lng, 64)
+	if err != nil {
+		return nil, fmt.Errorf("can't read the longitude %q", lng)
+	}
+	if !p.Valid() {
+		return nil, fmt.Errorf("%s, %s isn't a place on the map", lat, lng)
+	}
+	return &p, nil
+}
+
+func blankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
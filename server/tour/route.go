@@ -0,0 +1,194 @@
+// Package tour lays a band's shows out day by day, so a routing can be
+// checked for double bookings and long drives before anything is confirmed.
+package tour
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	earthRadiusKm = 6371.0
+	kmPerMile     = 1.609344
+	// LongDriveKm is the most we expect a band to drive between two shows
+	// on consecutive days without a travel day in between.
+	LongDriveKm = 800.0
+	DateLayout  = "2006-01-02"
+	// MaxDays is the longest a tour can run.
+	MaxDays = 366
+	// maxSpreadDays is how far past either end of a tour the routing
+	// widens to take in shows booked outside it.
+	maxSpreadDays = 90
+)
+
+// Point is a location in decimal degrees.
+type Point struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Valid reports whether the point is somewhere on the globe.
+func (p Point) Valid() bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180
+}
+
+// DistanceKm is the great circle distance between two points. Roads are
+// longer, but it is close enough to spot a routing that doesn't work.
+func DistanceKm(a, b Point) float64 {
+	lat1, lat2 := a.Latitude*math.Pi/180, b.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Stop is a show on the routing.
+type Stop struct {
+	ShowID    int32     `json:"show_id"`
+	Date      time.Time `json:"-"`
+	Venue     string    `json:"venue"`
+	City      string    `json:"city"`
+	Status    string    `json:"status"`
+	Location  *Point    `json:"location"`
+	Cancelled bool      `json:"-"`
+	// DistanceKm is the distance from the previous show that's going
+	// ahead, when both have coordinates.
+	DistanceKm *float64 `json:"distance_km"`
+	DistanceMi *float64 `json:"distance_mi"`
+}
+
+// Day is one date of the routing. A day without any shows going ahead is an
+// off day.
+type Day struct {
+	Date  string `json:"date"`
+	Off   bool   `json:"off"`
+	Stops []Stop `json:"shows"`
+}
+
+type ConflictKind string
+
+const (
+	ConflictDoubleBooked    ConflictKind = "double_booked"
+	ConflictOutsideTour     ConflictKind = "outside_tour"
+	ConflictLongDrive       ConflictKind = "long_drive"
+	ConflictMissingLocation ConflictKind = "missing_location"
+)
+
+// Conflict is a problem with the routing on a given date.
+type Conflict struct {
+	Kind    ConflictKind `json:"kind"`
+	Date    string       `json:"date"`
+	ShowIDs []int32      `json:"show_ids"`
+	Message string       `json:"message"`
+}
+
+type Routing struct {
+	Days      []Day      `json:"days"`
+	Conflicts []Conflict `json:"conflicts"`
+	ShowDays  int        `json:"show_days"`
+	OffDays   int        `json:"off_days"`
+	TotalKm   float64    `json:"total_km"`
+	TotalMi   float64    `json:"total_mi"`
+}
+
+// Route lays out every date from start to end, widened to cover any shows
+// that fall outside it. Cancelled shows are listed on their day but are
+// skipped when measuring distances and looking for conflicts. Only the first
+// MaxDays of the tour are routed, and shows too far outside that to lay out
+// are reported as conflicts without a day.
+func Route(start, end time.Time, stops []Stop) Routing {
+	if limit := start.AddDate(0, 0, MaxDays-1); end.After(limit) {
+		end = limit
+	}
+	earliest := start.AddDate(0, 0, -maxSpreadDays)
+	latest := end.AddDate(0, 0, maxSpreadDays)
+
+	routing := Routing{Days: []Day{}, Conflicts: []Conflict{}}
+	var routed []Stop
+	for _, stop := range stops {
+		if stop.Date.Before(earliest) || stop.Date.After(latest) {
+			routing.conflict(ConflictOutsideTour, stop.Date.Format(DateLayout), []int32{stop.ShowID}, "this show is too far outside the tour's dates to route")
+			continue
+		}
+		routed = append(routed, stop)
+	}
+	stops = routed
+	sort.SliceStable(stops, func(i, j int) bool { return stops[i].Date.Before(stops[j].Date) })
+
+	first, last := start, end
+	for _, stop := range stops {
+		if stop.Date.Before(first) {
+			first = stop.Date
+		}
+		if stop.Date.After(last) {
+			last = stop.Date
+		}
+	}
+
+	var previous *Stop
+	i := 0
+	for date := first; !date.After(last); date = date.AddDate(0, 0, 1) {
+		day := Day{Date: date.Format(DateLayout), Off: true, Stops: []Stop{}}
+		var playing []int32
+		for ; i < len(stops) && !stops[i].Date.After(date); i++ {
+			stop := stops[i]
+			if !stop.Cancelled {
+				day.Off = false
+				playing = append(playing, stop.ShowID)
+				routing.measure(&stop, previous, day.Date)
+				previous = &stop
+			}
+			day.Stops = append(day.Stops, stop)
+		}
+
+		outside := date.Before(start) || date.After(end)
+		if day.Off {
+			if !outside {
+				routing.OffDays++
+			}
+		} else {
+			routing.ShowDays++
+		}
+		if len(playing) > 1 {
+			routing.conflict(ConflictDoubleBooked, day.Date, playing, fmt.Sprintf("%d shows are booked on the same day", len(playing)))
+		}
+		if outside && len(playing) > 0 {
+			routing.conflict(ConflictOutsideTour, day.Date, playing, "this show falls outside the tour's dates")
+		}
+		routing.Days = append(routing.Days, day)
+	}
+
+	// shows left off the routing were reported first, so put them back in
+	// date order
+	sort.SliceStable(routing.Conflicts, func(i, j int) bool { return routing.Conflicts[i].Date < routing.Conflicts[j].Date })
+	routing.TotalMi = routing.TotalKm / kmPerMile
+	return routing
+}
+
+// measure fills in the distance to stop from the previous show going ahead,
+// and flags a drive too long to make overnight.
+func (routing *Routing) measure(stop, previous *Stop, date string) {
+	if stop.Location == nil {
+		routing.conflict(ConflictMissingLocation, date, []int32{stop.ShowID}, fmt.Sprintf("%s has no coordinates, so the drive there can't be measured", stop.Venue))
+		return
+	} else if previous == nil || previous.Location == nil {
+		return
+	}
+
+	km := DistanceKm(*previous.Location, *stop.Location)
+	mi := km / kmPerMile
+	stop.DistanceKm, stop.DistanceMi = &km, &mi
+	routing.TotalKm += km
+
+	days := int(math.Round(stop.Date.Sub(previous.Date).Hours() / 24))
+	if days <= 1 && km > LongDriveKm {
+		routing.conflict(ConflictLongDrive, date, []int32{previous.ShowID, stop.ShowID}, fmt.Sprintf("%.0f km from %s with no travel day", km, previous.Venue))
+	}
+}
+
+func (routing *Routing) conflict(kind ConflictKind, date string, showIDs []int32, message string) {
+	routing.Conflicts = append(routing.Conflicts, Conflict{Kind: kind, Date: date, ShowIDs: showIDs, Message: message})
+}
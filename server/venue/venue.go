@@ -0,0 +1,154 @@
+// Package venue describes what a room has to offer technically, so a band
+// can check its rider against it instead of re-reading the venue's PDF.
+package venue
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jkellogg01/rider/server/catalog"
+)
+
+var (
+	ErrCountNegative     = errors.New("channel, mix and item counts can't be negative")
+	ErrStageSizeNegative = errors.New("stage dimensions can't be negative")
+	ErrMicModelMissing   = errors.New("mic locker entries need a model or an item from the catalog")
+	ErrBacklineMissing   = errors.New("backline entries need a name or an item from the catalog")
+	ErrPowerInvalid      = errors.New("power supplies need a voltage and an amp rating")
+	ErrContactMissing    = errors.New("contacts need a name")
+	ErrCatalogUnknown    = errors.New("no matching catalog item")
+)
+
+// Spec is a venue's technical specification. Like a rider document it is
+// stored as JSON, so it can grow without reshaping the venue table.
+type Spec struct {
+	Console  *Console        `json:"console,omitempty"`
+	Mics     []MicStock      `json:"mics"`
+	Backline []BacklineStock `json:"backline"`
+	Stage    *Stage          `json:"stage,omitempty"`
+	Power    []PowerSupply   `json:"power"`
+	Contacts []Contact       `json:"contacts"`
+	Notes    string          `json:"notes"`
+}
+
+// Console is the front of house desk the venue provides.
+type Console struct {
+	Model string `json:"model"`
+	// Channels is how many inputs the desk can take at once, including any
+	// stage boxes that come with it.
+	Channels     int `json:"channels"`
+	MonitorMixes int `json:"monitor_mixes"`
+	// MonitorConsole is set when monitors are mixed from a separate desk.
+	MonitorConsole string `json:"monitor_console,omitempty"`
+}
+
+// MicStock is a model of mic or DI in the venue's locker.
+type MicStock struct {
+	Model string `json:"model"`
+	// CatalogID refers to the mic's entry in the equipment catalog.
+	CatalogID string `json:"catalog_id,omitempty"`
+	// Count defaults to one when left out. An explicit zero lists a model
+	// the venue has none of.
+	Count *int `json:"count,omitempty"`
+}
+
+type BacklineStock struct {
+	Name string `json:"name"`
+	// CatalogID refers to the item's entry in the equipment catalog.
+	CatalogID string `json:"catalog_id,omitempty"`
+	// Count defaults to one when left out. An explicit zero lists an item
+	// the venue has none of.
+	Count *int   `json:"count,omitempty"`
+	Notes string `json:"notes"`
+}
+
+// Available is how many the venue has, going by Count.
+func (m MicStock) Available() int {
+	return available(m.Count)
+}
+
+// Available is how many the venue has, going by Count.
+func (b BacklineStock) Available() int {
+	return available(b.Count)
+}
+
+func available(count *int) int {
+	if count == nil {
+		return 1
+	}
+	return *count
+}
+
+// Stage is measured in metres, the same as a rider's stage plot.
+type Stage struct {
+	Width  float64 `json:"width"`
+	Depth  float64 `json:"depth"`
+	Height float64 `json:"height"`
+}
+
+// PowerSupply is a kind of outlet or tie-in available on stage.
+type PowerSupply struct {
+	Description string  `json:"description"`
+	Voltage     int     `json:"voltage"`
+	Amps        float64 `json:"amps"`
+	Connector   string  `json:"connector"`
+	Count       int     `json:"count"`
+}
+
+type Contact struct {
+	Name  string `json:"name"`
+	Role  string `json:"role"`
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+}
+
+func Parse(raw []byte) (Spec, error) {
+	var spec Spec
+	if len(raw) == 0 {
+		return spec, nil
+	}
+	err := json.Unmarshal(raw, &spec)
+	return spec, err
+}
+
+func (s Spec) Validate() error {
+	if s.Console != nil && (s.Console.Channels < 0 || s.Console.MonitorMixes < 0) {
+		return ErrCountNegative
+	}
+	for _, mic := range s.Mics {
+		if strings.TrimSpace(mic.Model) == "" && mic.CatalogID == "" {
+			return ErrMicModelMissing
+		} else if _, ok := catalog.Lookup(mic.CatalogID); mic.CatalogID != "" && !ok {
+			return fmt.Errorf("%w: %q", ErrCatalogUnknown, mic.CatalogID)
+		} else if mic.Available() < 0 {
+			return fmt.Errorf("%w: %s", ErrCountNegative, mic.Model)
+		}
+	}
+	for _, item := range s.Backline {
+		if strings.TrimSpace(item.Name) == "" && item.CatalogID == "" {
+			return ErrBacklineMissing
+		} else if _, ok := catalog.Lookup(item.CatalogID); item.CatalogID != "" && !ok {
+			return fmt.Errorf("%w: %q", ErrCatalogUnknown, item.CatalogID)
+		} else if item.Available() < 0 {
+			return fmt.Errorf("%w: %s", ErrCountNegative, item.Name)
+		}
+	}
+	if s.Stage != nil && (s.Stage.Width < 0 || s.Stage.Depth < 0 || s.Stage.Height < 0) {
+		return ErrStageSizeNegative
+	}
+	for _, supply := range s.Power {
+		if supply.Voltage <= 0 || supply.Amps <= 0 {
+			return fmt.Errorf("%w: %q", ErrPowerInvalid, supply.Description)
+		} else if supply.Count < 0 {
+			return fmt.Errorf("%w: %s", ErrCountNegative, supply.Description)
+		}
+	}
+	for _, contact := range s.Contacts {
+		if strings.TrimSpace(contact.Name) == "" {
+			return ErrContactMissing
+		}
+	}
+	return nil
+}
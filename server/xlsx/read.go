@@ -0,0 +1,264 @@
+// Package xlsx reads and writes the cell text of simple Office Open XML
+// spreadsheets. Formatting, formulas and every sheet but the first are
+// ignored, which is all an input list needs.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNoSheet  = errors.New("the workbook has no sheets")
+	ErrTooLarge = errors.New("the workbook is too large")
+)
+
+const (
+	// maxColumns and maxRows are the most a spreadsheet application allows,
+	// column XFD and row 1048576.
+	maxColumns = 16384
+	maxRows    = 1 << 20
+	// maxCells bounds the cells filled in across the whole sheet, including
+	// the blanks before a far away cell.
+	maxCells = 1 << 20
+	// maxPartBytes bounds each part of the workbook once it's unzipped, so
+	// a small upload can't expand without limit.
+	maxPartBytes = 64 << 20
+)
+
+type workbook struct {
+	Sheets []struct {
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type relationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// richText is both a shared string and an inline string: either plain text
+// or runs of formatted text.
+type richText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t richText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+type sharedStrings struct {
+	Items []richText `xml:"si"`
+}
+
+type worksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline richText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// Read returns the text of every row on the workbook's first sheet, with
+// empty rows and cells filled in so that each value sits in its own row and
+// column.
+func Read(r io.ReaderAt, size int64) ([][]string, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheet(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared sharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		err = decode(f, &shared)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, ErrNoSheet
+	}
+	var sheet worksheet
+	err = decode(f, &sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	filled := 0
+	for _, row := range sheet.Rows {
+		if row.Number > maxRows {
+			return nil, fmt.Errorf("row %d is past the last row a spreadsheet can have", row.Number)
+		}
+		// rows with nothing in them are left out of the file, but callers
+		// number rows the way the spreadsheet shows them
+		for row.Number > len(rows)+1 {
+			rows = append(rows, nil)
+		}
+
+		var cells []string
+		for i, cell := range row.Cells {
+			column := i
+			if cell.Ref != "" {
+				column, err = columnIndex(cell.Ref)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if column >= maxColumns {
+				return nil, fmt.Errorf("cell %s is past the last column a spreadsheet can have", cell.Ref)
+			}
+			if column >= len(cells) {
+				filled += column + 1 - len(cells)
+				if filled > maxCells {
+					return nil, ErrTooLarge
+				}
+				cells = append(cells, make([]string, column+1-len(cells))...)
+			}
+
+			switch cell.Type {
+			case "s":
+				n, err := strconv.Atoi(cell.Value)
+				if err != nil || n < 0 || n >= len(shared.Items) {
+					return nil, fmt.Errorf("cell %s refers to a missing shared string", cell.Ref)
+				}
+				cells[column] = shared.Items[n].String()
+			case "inlineStr":
+				cells[column] = cell.Inline.String()
+			case "b":
+				cells[column] = map[string]string{"1": "TRUE", "0": "FALSE"}[cell.Value]
+			default:
+				cells[column] = cell.Value
+			}
+		}
+		rows = append(rows, cells)
+	}
+	return rows, nil
+}
+
+// firstSheet follows the workbook's relationships to find the part holding
+// its first sheet.
+func firstSheet(files map[string]*zip.File) (string, error) {
+	var book workbook
+	f, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", errors.New("not an xlsx workbook")
+	}
+	err := decode(f, &book)
+	if err != nil {
+		return "", err
+	} else if len(book.Sheets) == 0 {
+		return "", ErrNoSheet
+	}
+
+	var rels relationships
+	if f, ok := files["xl/_rels/workbook.xml.rels"]; ok {
+		err = decode(f, &rels)
+		if err != nil {
+			return "", err
+		}
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != book.Sheets[0].RelID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return "xl/worksheets/sheet1.xml", nil
+}
+
+func decode(f *zip.File, v any) error {
+	if f.UncompressedSize64 > maxPartBytes {
+		return ErrTooLarge
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	// the size in the zip header can't be trusted, so the limit is enforced
+	// while reading too
+	limited := &limitReader{r: rc, n: maxPartBytes}
+	err = xml.NewDecoder(limited).Decode(v)
+	if limited.n <= 0 {
+		return ErrTooLarge
+	}
+	return err
+}
+
+// limitReader is io.LimitReader, but reaching the limit is an error rather
+// than the end of the file.
+type limitReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		return 0, ErrTooLarge
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
+
+// columnIndex converts the letters of a cell reference like "AB12" to a zero
+// based column number.
+func columnIndex(ref string) (int, error) {
+	column := 0
+	letters := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A') + 1
+		letters++
+		if column > maxColumns {
+			return 0, fmt.Errorf("cell %q is past the last column a spreadsheet can have", ref)
+		}
+	}
+	if letters == 0 {
+		return 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	return column - 1, nil
+}
@@ -0,0 +1,193 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Sheet is a table to write to its own worksheet. The header row is bold and
+// frozen so it stays put while scrolling.
+type Sheet struct {
+	Name    string
+	Headers []string
+	Rows    [][]string
+}
+
+const (
+	maxSheetName   = 31
+	minColumnWidth = 6
+	maxColumnWidth = 60
+)
+
+// Write saves the sheets as a workbook. Cells that look like whole numbers
+// are written as numbers so they sort properly, and everything else as
+// inline strings so no shared string table is needed.
+func Write(w io.Writer, sheets []Sheet) error {
+	archive := zip.NewWriter(w)
+	add := func(name, content string) error {
+		f, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(f, xml.Header+content)
+		return err
+	}
+
+	var overrides, sheetEntries, rels strings.Builder
+	for i, sheet := range sheets {
+		n := i + 1
+		fmt.Fprintf(&overrides, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&sheetEntries, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(sheetName(sheet.Name, n)), n, n)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+	}
+	stylesID := len(sheets) + 1
+	fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, stylesID)
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			overrides.String() + `</Types>`},
+		{"_rels/.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + sheetEntries.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` + rels.String() + `</Relationships>`},
+		// style 1 is the bold, shaded header
+		{"xl/styles.xml", `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+			`<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>` +
+			`<fill><patternFill patternType="solid"><fgColor rgb="FFE7E6E6"/><bgColor indexed="64"/></patternFill></fill></fills>` +
+			`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+			`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+			`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+			`<xf numFmtId="0" fontId="1" fillId="2" borderId="0" xfId="0" applyFont="1" applyFill="1"/></cellXfs>` +
+			`</styleSheet>`},
+	}
+	for _, part := range parts {
+		err := add(part.name, part.content)
+		if err != nil {
+			return err
+		}
+	}
+	for i, sheet := range sheets {
+		err := add(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), worksheetXML(sheet))
+		if err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func worksheetXML(sheet Sheet) string {
+	var b strings.Builder
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if len(sheet.Headers) > 0 {
+		b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	}
+
+	widths := columnWidths(sheet)
+	if len(widths) > 0 {
+		b.WriteString("<cols>")
+		for i, width := range widths {
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, width)
+		}
+		b.WriteString("</cols>")
+	}
+
+	b.WriteString("<sheetData>")
+	n := 0
+	if len(sheet.Headers) > 0 {
+		n++
+		writeRow(&b, n, sheet.Headers, true)
+	}
+	for _, row := range sheet.Rows {
+		n++
+		writeRow(&b, n, row, false)
+	}
+	b.WriteString("</sheetData></worksheet>")
+	return b.String()
+}
+
+func writeRow(b *strings.Builder, n int, cells []string, header bool) {
+	fmt.Fprintf(b, `<row r="%d">`, n)
+	for i, cell := range cells {
+		ref := columnName(i) + strconv.Itoa(n)
+		style := ""
+		if header {
+			style = ` s="1"`
+		}
+		if !header && wholeNumber(cell) {
+			fmt.Fprintf(b, `<c r="%s"%s><v>%s</v></c>`, ref, style, cell)
+			continue
+		}
+		fmt.Fprintf(b, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, escape(cell))
+	}
+	b.WriteString("</row>")
+}
+
+// wholeNumber reports whether a cell can be stored as a number without
+// changing how it reads, so "007" and "+1" stay as text.
+func wholeNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil && !strings.HasPrefix(s, "+") && (s == "0" || !strings.HasPrefix(s, "0"))
+}
+
+func columnWidths(sheet Sheet) []int {
+	var widths []int
+	measure := func(cells []string) {
+		for i, cell := range cells {
+			for len(widths) <= i {
+				widths = append(widths, minColumnWidth)
+			}
+			widths[i] = min(max(widths[i], utf8.RuneCountInString(cell)+2), maxColumnWidth)
+		}
+	}
+	measure(sheet.Headers)
+	for _, row := range sheet.Rows {
+		measure(row)
+	}
+	return widths
+}
+
+// columnName converts a zero based column number to its letters, e.g. 27 to
+// "AB".
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// sheetName trims a name to what Excel accepts, falling back to a numbered
+// sheet.
+func sheetName(name string, n int) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > maxSheetName {
+		name = string(runes[:maxSheetName])
+	}
+	if strings.TrimSpace(name) == "" {
+		return fmt.Sprintf("Sheet%d", n)
+	}
+	return name
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}